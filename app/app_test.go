@@ -1,20 +1,28 @@
 package app_test
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/alicebob/miniredis"
 	"github.com/danielkraic/idmapper/app"
 	"github.com/danielkraic/idmapper/app/handlers"
+	"github.com/danielkraic/idmapper/app/idmappers"
+	"github.com/danielkraic/idmapper/clock"
+	"github.com/danielkraic/idmapper/idmapper"
 	"github.com/go-redis/redis"
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -109,6 +117,9 @@ func NewTestApp() (*TestApp, error) {
 	}
 	app.DB = db
 
+	// country IDs are canonicalized as in example configuration, other IDs are used as they are
+	app.Configuration.IDMappers.Lookup.Country = idmappers.LookupConfig{Trim: true, NormalizeUnicode: true, FoldCase: true}
+
 	// idmappers
 	err = app.SetupIDMappers()
 	if err != nil {
@@ -167,7 +178,7 @@ func TestAppCountry(t *testing.T) {
 
 	resp := httptest.NewRecorder()
 
-	h := handlers.NewIDMapperHandler(testApp.App.IDMappers.CountryCodes)
+	h := handlers.NewIDMapperHandler("country", testApp.App.IDMappers.CountryCodes, 0)
 	h.ServeHTTP(resp, req)
 	assert.Equal(t, http.StatusOK, resp.Code)
 
@@ -192,7 +203,7 @@ func TestAppCurrency(t *testing.T) {
 
 	resp := httptest.NewRecorder()
 
-	h := handlers.NewIDMapperHandler(testApp.App.IDMappers.CurrencyCodes)
+	h := handlers.NewIDMapperHandler("currency", testApp.App.IDMappers.CurrencyCodes, 0)
 	h.ServeHTTP(resp, req)
 	assert.Equal(t, http.StatusOK, resp.Code)
 
@@ -217,7 +228,7 @@ func TestAppLanguage(t *testing.T) {
 
 	resp := httptest.NewRecorder()
 
-	h := handlers.NewIDMapperHandler(testApp.App.IDMappers.LanguageCodes)
+	h := handlers.NewIDMapperHandler("language", testApp.App.IDMappers.LanguageCodes, 0)
 	h.ServeHTTP(resp, req)
 	assert.Equal(t, http.StatusOK, resp.Code)
 
@@ -242,7 +253,7 @@ func TestAppLanguageNotFound(t *testing.T) {
 
 	resp := httptest.NewRecorder()
 
-	h := handlers.NewIDMapperHandler(testApp.App.IDMappers.LanguageCodes)
+	h := handlers.NewIDMapperHandler("language", testApp.App.IDMappers.LanguageCodes, 0)
 	h.ServeHTTP(resp, req)
 	assert.Equal(t, http.StatusNotFound, resp.Code)
 }
@@ -262,3 +273,945 @@ func TestAppHealth(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, resp.Code)
 }
+
+func TestAppBuiltinFallback(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	testApp.App.Configuration.IDMappers.Loader.Builtin.Language = idmappers.BuiltinFallback
+
+	// language source is available, builtin data are not used
+	(*testApp.SQLMock).ExpectQuery("select id, name from country").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	err = testApp.App.SetupIDMappers()
+	assert.Nil(t, err)
+	_, found := testApp.App.IDMappers.LanguageCodes.Get("de")
+	assert.False(t, found)
+
+	// language source becomes unavailable, failure is reported and data loaded before are kept
+	testApp.HTTPTestServer.Close()
+	err = testApp.App.IDMappers.LanguageCodes.Reload()
+	assert.NotNil(t, err)
+	name, found := testApp.App.IDMappers.LanguageCodes.Get("en")
+	assert.True(t, found)
+	assert.Equal(t, "English", name)
+	_, found = testApp.App.IDMappers.LanguageCodes.Get("de")
+	assert.False(t, found)
+
+	// builtin ISO data are used if language source is unavailable before any data were loaded
+	(*testApp.SQLMock).ExpectQuery("select id, name from country").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	err = testApp.App.SetupIDMappers()
+	assert.Nil(t, err)
+	name, found = testApp.App.IDMappers.LanguageCodes.Get("de")
+	assert.True(t, found)
+	assert.Equal(t, "German", name)
+}
+
+func TestAppBuiltinNone(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	// without builtin data, IDMappers cannot be created while source is unavailable
+	testApp.HTTPTestServer.Close()
+	(*testApp.SQLMock).ExpectQuery("select id, name from country").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	err = testApp.App.SetupIDMappers()
+	assert.NotNil(t, err)
+}
+
+func TestAppCountryCanonicalID(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	for _, id := range []string{"SK", " sk", "Sk"} {
+		req, err := http.NewRequest(http.MethodGet, "/v1/country/", nil)
+		assert.Nil(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+
+		resp := httptest.NewRecorder()
+
+		h := handlers.NewIDMapperHandler("country", testApp.App.IDMappers.CountryCodes, 0)
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var idMapperResponse handlers.IDMapperResponse
+		err = json.NewDecoder(resp.Body).Decode(&idMapperResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, "sk", idMapperResponse.ID)
+		assert.Equal(t, "Slovakia", idMapperResponse.Name)
+	}
+}
+
+func TestAppLeaderRenewal(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	// language source blocks after initial load, so its reload occupies the only reload worker
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) > 1 {
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode([]handlers.IDMapperResponse{{ID: "en", Name: "English"}})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := &testApp.App.Configuration.IDMappers
+	config.Loader.URLs.Language = server.URL
+	config.Leader.Enabled = true
+	config.Leader.TTL = time.Minute
+	config.Leader.RenewInterval = 10 * time.Millisecond
+	config.Reloader.Workers = 1
+	config.Reloader.Language.RunOnStart = true
+	(*testApp.SQLMock).ExpectQuery("select id, name from country").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("sk", "Slovakia"))
+	err = testApp.App.SetupIDMappers()
+	assert.Nil(t, err)
+
+	testApp.App.IDMappers.RunReloader(logrus.New())
+	defer testApp.App.IDMappers.StopReloader()
+	for deadline := time.Now().Add(time.Second); atomic.LoadInt32(&requests) < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	// lease is renewed while reload is in progress
+	testApp.Miniredis.FastForward(30 * time.Second)
+	renewed := func() bool {
+		return testApp.Miniredis.TTL(config.Leader.Key) > 30*time.Second
+	}
+	for deadline := time.Now().Add(time.Second); !renewed() && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, renewed())
+}
+
+func TestAppCountryAliases(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	// aliases are case-folded like requested IDs
+	testApp.App.Configuration.IDMappers.Lookup.Country.Aliases = map[string]string{"UK": "GB"}
+	(*testApp.SQLMock).ExpectQuery("select id, name from country").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("SK", "Slovakia").AddRow("GB", "United Kingdom"))
+	err = testApp.App.SetupIDMappers()
+	assert.Nil(t, err)
+
+	for _, id := range []string{"UK", "uk", " Uk", "GB"} {
+		canonicalID, name, found := testApp.App.IDMappers.CountryCodes.Lookup(id)
+		assert.True(t, found, id)
+		assert.Equal(t, "gb", canonicalID)
+		assert.Equal(t, "United Kingdom", name)
+	}
+}
+
+func TestAppLookupNotNormalizedByDefault(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	testApp.Miniredis.HSet(testApp.App.Configuration.IDMappers.Reloader.Currency.RedisHashName, "CHF", "franc")
+	assert.Nil(t, testApp.App.IDMappers.CurrencyCodes.Reload())
+
+	canonicalID, name, found := testApp.App.IDMappers.CurrencyCodes.Lookup("CHF")
+	assert.True(t, found)
+	assert.Equal(t, "CHF", canonicalID)
+	assert.Equal(t, "franc", name)
+
+	for _, id := range []string{"chf", " CHF"} {
+		_, _, found = testApp.App.IDMappers.CurrencyCodes.Lookup(id)
+		assert.False(t, found, id)
+	}
+}
+
+func TestAppReloaderStateAfterStartup(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	config := &testApp.App.Configuration.IDMappers
+	config.Reloader.State.Store = "redis"
+	store, err := idmappers.NewRedisStateStore(testApp.App.RedisClient, config.Reloader.State.RedisKey)
+	assert.Nil(t, err)
+
+	// reload of country codes was missed while instance was not running
+	assert.Nil(t, store.Save("country", time.Now().Add(-48*time.Hour)))
+
+	(*testApp.SQLMock).ExpectQuery("select id, name from country").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("sk", "Slovakia"))
+	start := time.Now()
+	err = testApp.App.SetupIDMappers()
+	assert.Nil(t, err)
+
+	// initial load is recorded as successful reload, so it is not caught up again
+	testApp.App.IDMappers.RunReloader(logrus.New())
+	defer testApp.App.IDMappers.StopReloader()
+	time.Sleep(100 * time.Millisecond)
+
+	for _, job := range testApp.App.IDMappers.Jobs() {
+		assert.Equal(t, uint64(0), job.Successes+job.Failures, job.Name)
+	}
+	assert.Nil(t, (*testApp.SQLMock).ExpectationsWereMet())
+
+	last, err := store.Load("country")
+	assert.Nil(t, err)
+	assert.False(t, last.Before(start))
+}
+
+func TestAppJobs(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	testApp.App.IDMappers.RunReloader(logrus.New())
+
+	req, err := http.NewRequest(http.MethodGet, "/admin/jobs", nil)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+
+	h := handlers.NewJobsHandler(testApp.App.IDMappers)
+	h.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var jobs []handlers.JobResponse
+	err = json.NewDecoder(resp.Body).Decode(&jobs)
+	assert.Nil(t, err)
+
+	names := []string{}
+	for _, job := range jobs {
+		names = append(names, job.Name)
+	}
+	assert.ElementsMatch(t, []string{"currency", "country", "language"}, names)
+}
+
+func TestAppJobAction(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	testApp.App.IDMappers.RunReloader(logrus.New())
+
+	h := handlers.NewJobActionHandler(testApp.App.IDMappers, testApp.App.IDMappers.PauseJob)
+	for name, status := range map[string]int{"language": http.StatusNoContent, "unknown": http.StatusNotFound} {
+		req, err := http.NewRequest(http.MethodPost, "/admin/jobs/", nil)
+		assert.Nil(t, err)
+		req = mux.SetURLVars(req, map[string]string{"name": name})
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, status, resp.Code, name)
+	}
+
+	paused := func() map[string]bool {
+		req, err := http.NewRequest(http.MethodGet, "/admin/jobs", nil)
+		assert.Nil(t, err)
+		resp := httptest.NewRecorder()
+		handlers.NewJobsHandler(testApp.App.IDMappers).ServeHTTP(resp, req)
+
+		var jobs []handlers.JobResponse
+		assert.Nil(t, json.NewDecoder(resp.Body).Decode(&jobs))
+		result := make(map[string]bool)
+		for _, job := range jobs {
+			result[job.Name] = job.Paused
+		}
+		return result
+	}
+	assert.Equal(t, map[string]bool{"currency": false, "country": false, "language": true}, paused())
+
+	req, err := http.NewRequest(http.MethodPost, "/admin/jobs/", nil)
+	assert.Nil(t, err)
+	req = mux.SetURLVars(req, map[string]string{"name": "language"})
+	resp := httptest.NewRecorder()
+	handlers.NewJobActionHandler(testApp.App.IDMappers, testApp.App.IDMappers.ResumeJob).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	assert.Equal(t, map[string]bool{"currency": false, "country": false, "language": false}, paused())
+}
+
+func TestAppShutdownReloader(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	testApp.App.IDMappers.RunReloader(logrus.New())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	running, err := testApp.App.IDMappers.ShutdownReloader(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, running)
+
+	for _, job := range testApp.App.IDMappers.Jobs() {
+		assert.True(t, job.NextRun.IsZero(), job.Name)
+	}
+}
+
+func TestAppJobRunBlackout(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	testApp.App.Configuration.IDMappers.Reloader.Language.Blackouts = []idmappers.WindowConfig{{
+		Start:    time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+		End:      time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		TimeZone: "UTC",
+	}}
+	testApp.App.IDMappers.RunReloader(logrus.New())
+	defer testApp.App.IDMappers.StopReloader()
+
+	router := app.CreateAdminRouter(testApp.App.IDMappers)
+	for url, status := range map[string]int{"/admin/jobs/language/run": http.StatusConflict, "/admin/jobs/language/run?force=true": http.StatusNoContent} {
+		req, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.Nil(t, err)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, status, resp.Code, url)
+	}
+}
+
+func TestAppAdminMappers(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	router := app.CreateAdminRouter(testApp.App.IDMappers)
+	request := func(method string, url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, nil)
+		assert.Nil(t, err)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := request(http.MethodGet, "/admin/mappers")
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var mappers []handlers.MapperResponse
+	err = json.NewDecoder(resp.Body).Decode(&mappers)
+	assert.Nil(t, err)
+	assert.Len(t, mappers, 3)
+	for _, mapper := range mappers {
+		assert.Equal(t, 2, mapper.Size, mapper.Name)
+		assert.NotEmpty(t, mapper.Version, mapper.Name)
+		assert.NotNil(t, mapper.LoadedAt, mapper.Name)
+		assert.Nil(t, mapper.LastReload, mapper.Name)
+	}
+
+	// reload jobs cannot be run until reloader is started
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, "/admin/mappers/language/reload").Code)
+
+	// language is in blackout, so it can be reloaded only with force
+	testApp.App.Configuration.IDMappers.Reloader.Language.Blackouts = []idmappers.WindowConfig{{Start: "2000-01-01", End: "2100-01-01"}}
+	testApp.App.IDMappers.RunReloader(logrus.New())
+	defer testApp.App.IDMappers.StopReloader()
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, "/admin/mappers/language/reload").Code)
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, "/admin/mappers/language/reload?async=true").Code)
+
+	// language is reloaded from http server
+	resp = request(http.MethodPost, "/admin/mappers/language/reload?force=true")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	err = json.NewDecoder(resp.Body).Decode(&mappers)
+	assert.Nil(t, err)
+	assert.Len(t, mappers, 1)
+	assert.Equal(t, "language", mappers[0].Name)
+	assert.NotNil(t, mappers[0].LastReload)
+	assert.Empty(t, mappers[0].LastError)
+	assert.Equal(t, uint64(1), testApp.App.IDMappers.Jobs()[2].Successes)
+
+	// PostgreSQL mock expects single query only, so reload of country fails and its data are kept
+	countryVersion := testApp.App.IDMappers.CountryCodes.Hash()
+	resp = request(http.MethodPost, "/admin/mappers/reload?force=true")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	err = json.NewDecoder(resp.Body).Decode(&mappers)
+	assert.Nil(t, err)
+	assert.Len(t, mappers, 3)
+	for _, mapper := range mappers {
+		assert.NotNil(t, mapper.LastReload, mapper.Name)
+		assert.Equal(t, mapper.Name == "country", mapper.LastError != "", mapper.Name)
+		if mapper.Name == "country" {
+			assert.Equal(t, countryVersion, mapper.Version)
+			assert.Equal(t, 2, mapper.Size)
+		}
+	}
+	assert.Equal(t, uint64(1), testApp.App.IDMappers.Jobs()[1].Failures)
+
+	assert.Equal(t, http.StatusNotFound, request(http.MethodPost, "/admin/mappers/unknown/reload").Code)
+
+	resp = request(http.MethodGet, "/admin/mappers/country/snapshot")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var snapshot handlers.SnapshotResponse
+	err = json.NewDecoder(resp.Body).Decode(&snapshot)
+	assert.Nil(t, err)
+	assert.Equal(t, "country", snapshot.Name)
+	assert.Equal(t, testApp.App.IDMappers.CountryCodes.Hash(), snapshot.Version)
+	values, _ := testApp.App.IDMappers.CountryCodes.Snapshot()
+	assert.Equal(t, values, snapshot.Values)
+
+	assert.Equal(t, http.StatusNotFound, request(http.MethodGet, "/admin/mappers/unknown/snapshot").Code)
+
+	// asynchronous reload runs reload jobs in background
+	assert.Equal(t, http.StatusAccepted, request(http.MethodPost, "/admin/mappers/language/reload?async=true&force=true").Code)
+	assert.Equal(t, http.StatusNotFound, request(http.MethodPost, "/admin/mappers/unknown/reload?async=true").Code)
+}
+
+func TestAppCaching(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	router := app.CreateRouter("/v1", nil, testApp.App.IDMappers, 0, app.CacheConfig{Country: time.Hour}, app.ReadinessConfig{})
+	request := func(method string, url string, header http.Header) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, strings.NewReader(`{"ids": ["sk"]}`))
+		assert.Nil(t, err)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := request(http.MethodGet, "/v1/country/sk", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "public, max-age=3600", resp.Header().Get("Cache-Control"))
+	etag := resp.Header().Get("ETag")
+	lastModified := resp.Header().Get("Last-Modified")
+	assert.NotEmpty(t, etag)
+	assert.NotEmpty(t, lastModified)
+
+	// ETag is derived from entry, so it does not depend on form of requested ID
+	assert.Equal(t, etag, request(http.MethodGet, "/v1/country/SK", nil).Header().Get("ETag"))
+	assert.NotEqual(t, etag, request(http.MethodGet, "/v1/country/us", nil).Header().Get("ETag"))
+
+	resp = request(http.MethodGet, "/v1/country/sk", http.Header{"If-None-Match": {`"other", ` + etag}})
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Empty(t, resp.Body.String())
+	assert.Equal(t, etag, resp.Header().Get("ETag"))
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/v1/country/sk", http.Header{"If-None-Match": {`"other"`}}).Code)
+	assert.Equal(t, http.StatusNotModified, request(http.MethodGet, "/v1/country/sk", http.Header{"If-Modified-Since": {lastModified}}).Code)
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/v1/country/sk", http.Header{"If-Modified-Since": {"Mon, 02 Jan 2006 15:04:05 GMT"}}).Code)
+	// If-Modified-Since is ignored if If-None-Match is present
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/v1/country/sk", http.Header{"If-None-Match": {`"other"`}, "If-Modified-Since": {lastModified}}).Code)
+
+	assert.Equal(t, "no-cache", request(http.MethodGet, "/v1/language/sk", nil).Header().Get("Cache-Control"))
+
+	// list
+	resp = request(http.MethodGet, "/v1/country?limit=1", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	listETag := resp.Header().Get("ETag")
+	assert.Equal(t, http.StatusNotModified, request(http.MethodGet, "/v1/country?limit=1", http.Header{"If-None-Match": {listETag}}).Code)
+	resp = request(http.MethodGet, "/v1/country?limit=1", http.Header{"If-None-Match": {listETag}, "Accept": {"application/x-ndjson"}})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NotEqual(t, listETag, resp.Header().Get("ETag"))
+
+	// bulk
+	resp = request(http.MethodGet, "/v1/country/_bulk?id=sk&id=xx", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	bulkETag := resp.Header().Get("ETag")
+	assert.NotEmpty(t, bulkETag)
+	assert.Equal(t, http.StatusNotModified, request(http.MethodGet, "/v1/country/_bulk?id=sk&id=xx", http.Header{"If-None-Match": {bulkETag}}).Code)
+	assert.NotEqual(t, bulkETag, request(http.MethodGet, "/v1/country/_bulk?id=sk", nil).Header().Get("ETag"))
+	assert.Equal(t, http.StatusOK, request(http.MethodPost, "/v1/country/_bulk", http.Header{"If-None-Match": {"*"}}).Code)
+
+	// data of country change
+	(*testApp.SQLMock).ExpectQuery("select id, name from country").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("sk", "Slovakia"))
+	err = testApp.App.IDMappers.CountryCodes.Reload()
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/v1/country?limit=1", http.Header{"If-None-Match": {listETag}}).Code)
+}
+
+func TestAppContentNegotiation(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	router := app.CreateRouter("/v1", nil, testApp.App.IDMappers, 0, app.CacheConfig{}, app.ReadinessConfig{})
+
+	tests := []struct {
+		url         string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"/v1/country/sk", "", http.StatusOK, "application/json", `{"id":"sk","name":"Slovakia"}` + "\n"},
+		{"/v1/country/sk", "*/*", http.StatusOK, "application/json", `{"id":"sk","name":"Slovakia"}` + "\n"},
+		{"/v1/country/sk", "text/plain", http.StatusOK, "text/plain; charset=utf-8", "Slovakia\n"},
+		{"/v1/country/sk", "application/json;q=0.5, text/csv", http.StatusOK, "text/csv; charset=utf-8", "id,name\nsk,Slovakia\n"},
+		{"/v1/country/sk?format=csv", "application/json", http.StatusOK, "text/csv; charset=utf-8", "id,name\nsk,Slovakia\n"},
+		{"/v1/country/sk", "application/xml", http.StatusOK, "application/xml", `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<entry><id>sk</id><name>Slovakia</name></entry>`},
+		{"/v1/country/sk", "application/x-msgpack", http.StatusOK, "application/msgpack", "\x82\xa2id\xa2sk\xa4name\xa8Slovakia"},
+		{"/v1/country/sk", "image/png", http.StatusNotAcceptable, "", ""},
+		{"/v1/country/sk?format=yaml", "", http.StatusNotAcceptable, "", ""},
+		{"/v1/country/sk?format=ndjson", "", http.StatusNotAcceptable, "", ""},
+		{"/v1/country/_bulk?id=sk&id=xx", "text/csv", http.StatusOK, "text/csv; charset=utf-8", "id,name,found\nsk,Slovakia,true\nxx,,false\n"},
+		{"/v1/country/_bulk?id=sk&id=xx", "application/msgpack", http.StatusOK, "application/msgpack", "\x82\xa5found\x91\x82\xa2id\xa2sk\xa4name\xa8Slovakia\xa7missing\x91\xa2xx"},
+		{"/v1/country?format=text", "", http.StatusOK, "text/plain; charset=utf-8", "Slovakia\nUSA\n"},
+		{"/v1/country?limit=1", "application/xml", http.StatusOK, "application/xml", `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<list><items><entry><id>sk</id><name>Slovakia</name></entry></items><version>`},
+		{"/v1/country?sort=name", "application/x-ndjson", http.StatusOK, "application/x-ndjson", `{"id":"sk","name":"Slovakia"}` + "\n" + `{"id":"us","name":"USA"}` + "\n"},
+		{"/v1/country/_search?q=slovakia", "text/csv", http.StatusOK, "text/csv; charset=utf-8", "id,name,score\nsk,Slovakia,0.9\n"},
+		{"/v1/country/_search?q=slovakia", "application/msgpack", http.StatusOK, "application/msgpack", "\x81\xa7results\x91\x83\xa2id\xa2sk\xa4name\xa8Slovakia\xa5score\xcb\x3f\xec\xcc\xcc\xcc\xcc\xcc\xcd"},
+		{"/v1/country?limit=1", "application/msgpack", http.StatusOK, "application/msgpack", "\x83\xa5items\x91\x82\xa2id\xa2sk\xa4name\xa8Slovakia\xa7version"},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest(http.MethodGet, test.url, nil)
+		assert.Nil(t, err)
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, test.status, resp.Code, test.url, test.accept)
+		assert.Equal(t, "Accept", resp.Header().Get("Vary"), test.url, test.accept)
+		if test.status != http.StatusOK {
+			continue
+		}
+		assert.Equal(t, test.contentType, resp.Header().Get("Content-Type"), test.url, test.accept)
+		assert.True(t, strings.HasPrefix(resp.Body.String(), test.body), "%s %s: %q", test.url, test.accept, resp.Body.String())
+	}
+}
+
+func TestAppProblems(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	router := handlers.WithRequestID(app.CreateRouter("/v1", nil, testApp.App.IDMappers, 0, app.CacheConfig{}, app.ReadinessConfig{}))
+
+	tests := []struct {
+		method string
+		url    string
+		accept string
+		status int
+		kind   string
+		mapper string
+		id     string
+	}{
+		{http.MethodGet, "/v1/country/xx", "", http.StatusNotFound, "unknown-id", "country", "xx"},
+		{http.MethodGet, "/v1/country/xx", "text/csv", http.StatusNotFound, "unknown-id", "country", "xx"},
+		{http.MethodGet, "/v1/unknown/sk", "", http.StatusNotFound, "unknown-mapper", "unknown", ""},
+		{http.MethodGet, "/v1/country/" + strings.Repeat("x", 300), "", http.StatusBadRequest, "invalid-id", "country", strings.Repeat("x", 300)},
+		{http.MethodGet, "/v1/country/_bulk?id=sk&id=%01", "", http.StatusBadRequest, "invalid-id", "country", "\x01"},
+		{http.MethodGet, "/v1/country?limit=0", "", http.StatusBadRequest, "bad-request", "country", ""},
+		{http.MethodGet, "/v1/country/sk", "image/png", http.StatusNotAcceptable, "not-acceptable", "", ""},
+		{http.MethodPost, "/v1/country/sk", "", http.StatusMethodNotAllowed, "method-not-allowed", "", ""},
+		{http.MethodGet, "/unknown", "", http.StatusNotFound, "not-found", "", ""},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest(test.method, test.url, nil)
+		assert.Nil(t, err)
+		req.Header.Set("X-Request-ID", "test-request")
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, test.status, resp.Code, test.url)
+		assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"), test.url)
+		assert.Equal(t, "test-request", resp.Header().Get("X-Request-ID"), test.url)
+
+		var problem handlers.Problem
+		err = json.NewDecoder(resp.Body).Decode(&problem)
+		assert.Nil(t, err)
+		assert.Equal(t, "urn:idmapper:problem:"+test.kind, problem.Type, test.url)
+		assert.NotEmpty(t, problem.Title, test.url)
+		assert.Equal(t, test.status, problem.Status, test.url)
+		assert.Equal(t, req.URL.Path, problem.Instance, test.url)
+		assert.Equal(t, test.mapper, problem.Mapper, test.url)
+		assert.Equal(t, test.id, problem.ID, test.url)
+		assert.Equal(t, "test-request", problem.RequestID, test.url)
+	}
+
+	// request ID is generated if client does not set it
+	req, err := http.NewRequest(http.MethodGet, "/v1/country/sk", nil)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Len(t, resp.Header().Get("X-Request-ID"), 32)
+}
+
+func TestAppMapperNotLoaded(t *testing.T) {
+	idMapper, err := idmapper.NewIDMapper(idmapper.SourceReaderFunc(func() (idmapper.ValuesMap, error) {
+		return nil, fmt.Errorf("source is not available")
+	}))
+	assert.NotNil(t, err)
+
+	for _, h := range []http.Handler{
+		handlers.NewIDMapperHandler("test", idMapper, 0),
+		handlers.NewListHandler("test", idMapper, 0),
+		handlers.NewBulkHandler("test", idMapper, 0, 0),
+		handlers.NewSearchHandler("test", idMapper),
+	} {
+		req, err := http.NewRequest(http.MethodGet, "/v1/test/sk", nil)
+		assert.Nil(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "sk"})
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+		var problem handlers.Problem
+		err = json.NewDecoder(resp.Body).Decode(&problem)
+		assert.Nil(t, err)
+		assert.Equal(t, "urn:idmapper:problem:mapper-not-loaded", problem.Type)
+		assert.Equal(t, "test", problem.Mapper)
+		assert.NotEmpty(t, problem.RequestID)
+	}
+}
+
+func TestAppLiveness(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	req, err := http.NewRequest(http.MethodGet, "/livez", nil)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+	app.CreateRouter("", nil, testApp.App.IDMappers, 0, app.CacheConfig{}, app.ReadinessConfig{}).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `{"status":"ok"}`+"\n", resp.Body.String())
+}
+
+func TestAppReadiness(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	readiness := func(config app.ReadinessConfig) (int, handlers.ReadinessResponse) {
+		req, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+		assert.Nil(t, err)
+		resp := httptest.NewRecorder()
+		app.CreateRouter("", nil, testApp.App.IDMappers, 0, app.CacheConfig{}, config).ServeHTTP(resp, req)
+
+		var response handlers.ReadinessResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		assert.Nil(t, err)
+		return resp.Code, response
+	}
+
+	// backends are not reported until they are checked
+	status, response := readiness(app.ReadinessConfig{})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, response.Backends)
+
+	backendsChecked := func(check func(backend idmappers.BackendStatus) bool) bool {
+		backends := testApp.App.IDMappers.Backends()
+		for _, backend := range backends {
+			if !check(backend) {
+				return false
+			}
+		}
+		return len(backends) == 3
+	}
+	waitForBackends := func(check func(backend idmappers.BackendStatus) bool) {
+		for deadline := time.Now().Add(time.Second); !backendsChecked(check) && time.Now().Before(deadline); {
+			time.Sleep(time.Millisecond)
+		}
+		assert.True(t, backendsChecked(check))
+	}
+
+	err = testApp.App.IDMappers.RunBackendChecks(10*time.Millisecond, time.Second)
+	assert.Nil(t, err)
+	defer testApp.App.IDMappers.StopReloader()
+	waitForBackends(func(backend idmappers.BackendStatus) bool { return true })
+
+	status, response = readiness(app.ReadinessConfig{MaxDataAge: time.Hour})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ready", response.Status)
+	assert.Len(t, response.Mappers, 3)
+	for _, mapper := range response.Mappers {
+		assert.Equal(t, "ok", mapper.Status, mapper.Name)
+		assert.True(t, mapper.Critical, mapper.Name)
+		assert.NotNil(t, mapper.LoadedAt, mapper.Name)
+	}
+	backends := map[string]string{}
+	for _, backend := range response.Backends {
+		backends[backend.Name] = backend.Status
+		assert.NotNil(t, backend.CheckedAt, backend.Name)
+	}
+	assert.Equal(t, map[string]string{"redis": "ok", "postgresql": "ok", "http": "ok"}, backends)
+
+	// data of all mappers are too old
+	status, response = readiness(app.ReadinessConfig{MaxDataAge: time.Nanosecond})
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "not_ready", response.Status)
+	for _, mapper := range response.Mappers {
+		assert.Equal(t, "stale", mapper.Status, mapper.Name)
+	}
+
+	// unavailable backends are found by next check and do not affect readiness
+	testApp.Miniredis.Close()
+	testApp.HTTPTestServer.Close()
+	waitForBackends(func(backend idmappers.BackendStatus) bool { return backend.Name == "postgresql" || backend.Error != "" })
+	status, response = readiness(app.ReadinessConfig{})
+	assert.Equal(t, http.StatusOK, status)
+	for _, backend := range response.Backends {
+		assert.Equal(t, backend.Name != "postgresql", backend.Status == "error", backend.Name)
+		assert.Equal(t, backend.Name != "postgresql", backend.Error != "", backend.Name)
+	}
+}
+
+type testHealthChecker struct {
+	mappers []idmappers.MapperStatus
+}
+
+func (checker *testHealthChecker) Mappers() []idmappers.MapperStatus {
+	return checker.mappers
+}
+
+func (checker *testHealthChecker) Backends() []idmappers.BackendStatus {
+	return nil
+}
+
+func TestAppReadinessCritical(t *testing.T) {
+	loadedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	checker := &testHealthChecker{
+		mappers: []idmappers.MapperStatus{
+			{Name: "currency", Size: 2, LoadedAt: loadedAt},
+			{Name: "country", LastError: "source is not available"},
+		},
+	}
+	fakeClock := clock.NewFake(loadedAt.Add(time.Hour))
+
+	for critical, expected := range map[string]int{"": http.StatusServiceUnavailable, "currency": http.StatusOK, "country": http.StatusServiceUnavailable} {
+		names := []string{}
+		if critical != "" {
+			names = append(names, critical)
+		}
+
+		req, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+		assert.Nil(t, err)
+		resp := httptest.NewRecorder()
+		handlers.NewReadinessHandler(checker, fakeClock, names, 2*time.Hour).ServeHTTP(resp, req)
+		assert.Equal(t, expected, resp.Code, critical)
+
+		var response handlers.ReadinessResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		assert.Nil(t, err)
+		assert.Equal(t, "not_loaded", response.Mappers[1].Status)
+		assert.Equal(t, "source is not available", response.Mappers[1].LastError)
+		assert.Equal(t, critical == "" || critical == "country", response.Mappers[1].Critical, critical)
+		assert.Equal(t, "ok", response.Mappers[0].Status)
+		assert.Equal(t, "1h0m0s", response.Mappers[0].Age)
+	}
+
+	// age of data is measured by clock
+	fakeClock.Advance(2 * time.Hour)
+	req, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+	handlers.NewReadinessHandler(checker, fakeClock, []string{"currency"}, 2*time.Hour).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	var response handlers.ReadinessResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	assert.Nil(t, err)
+	assert.Equal(t, "stale", response.Mappers[0].Status)
+	assert.Equal(t, "3h0m0s", response.Mappers[0].Age)
+}
+
+func TestAppAdaptiveReloadInterval(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	config := &testApp.App.Configuration.IDMappers.Reloader.Language
+	config.Interval = 30 * time.Minute
+	config.Adaptive.Enabled = true
+	config.Adaptive.MinInterval = time.Minute
+	config.Adaptive.MaxInterval = time.Hour
+	testApp.App.IDMappers.RunReloader(logrus.New())
+	defer testApp.App.IDMappers.StopReloader()
+
+	// reload without change grows interval
+	assert.Nil(t, testApp.App.IDMappers.RunJob("language"))
+	replanned := func() bool {
+		return testApp.App.IDMappers.Jobs()[2].NextRun.After(time.Now().Add(59 * time.Minute))
+	}
+	for deadline := time.Now().Add(time.Second); !replanned() && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, replanned())
+
+	req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+	app.CreateRouter("", nil, testApp.App.IDMappers, 0, app.CacheConfig{}, app.ReadinessConfig{}).ServeHTTP(resp, req)
+	assert.Contains(t, resp.Body.String(), `idmapper_reload_interval_seconds{mapper="language"} 3600`)
+}
+
+func TestAppBulk(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	router := app.CreateRouter("/v1", nil, testApp.App.IDMappers, 3, app.CacheConfig{}, app.ReadinessConfig{})
+	expected := handlers.BulkResponse{
+		Found:   []handlers.IDMapperResponse{{ID: "sk", Name: "Slovakia"}, {ID: "us", Name: "USA"}},
+		Missing: []string{"xx"},
+	}
+
+	requests := []func() (*http.Request, error){
+		func() (*http.Request, error) {
+			return http.NewRequest(http.MethodPost, "/v1/country/_bulk", strings.NewReader(`{"ids":["us","xx","sk"]}`))
+		},
+		func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet, "/v1/country/_bulk?id=us&id=xx&id=sk", nil)
+		},
+	}
+	for _, newRequest := range requests {
+		req, err := newRequest()
+		assert.Nil(t, err)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var bulkResponse handlers.BulkResponse
+		err = json.Unmarshal(resp.Body.Bytes(), &bulkResponse)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, bulkResponse)
+	}
+
+	for url, body := range map[string]string{"/v1/country/_bulk": `{"ids":["a","b","c","d"]}`, "/v1/currency/_bulk": `[`} {
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		assert.Nil(t, err)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Code, url)
+	}
+
+	// body is limited according to maximal batch size
+	req, err := http.NewRequest(http.MethodPost, "/v1/country/_bulk", strings.NewReader(`{"ids":["`+strings.Repeat("x", 10000)+`"]}`))
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	var problem handlers.Problem
+	err = json.NewDecoder(resp.Body).Decode(&problem)
+	assert.Nil(t, err)
+	assert.Equal(t, "urn:idmapper:problem:request-too-large", problem.Type)
+	assert.Equal(t, "country", problem.Mapper)
+}
+
+func TestAppList(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	router := app.CreateRouter("/v1", nil, testApp.App.IDMappers, 0, app.CacheConfig{}, app.ReadinessConfig{})
+	list := func(url string) handlers.ListResponse {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.Nil(t, err)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code, url)
+
+		var listResponse handlers.ListResponse
+		assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &listResponse), url)
+		return listResponse
+	}
+
+	page := list("/v1/country?limit=1")
+	assert.Equal(t, []handlers.IDMapperResponse{{ID: "sk", Name: "Slovakia"}}, page.Items)
+	assert.NotEmpty(t, page.NextCursor)
+	page = list("/v1/country?limit=1&cursor=" + page.NextCursor)
+	assert.Equal(t, []handlers.IDMapperResponse{{ID: "us", Name: "USA"}}, page.Items)
+	assert.Empty(t, page.NextCursor)
+
+	page = list("/v1/language?sort=name")
+	assert.Equal(t, []handlers.IDMapperResponse{{ID: "en", Name: "English"}, {ID: "sk", Name: "Slovak"}}, page.Items)
+	page = list("/v1/currency?id_prefix=u")
+	assert.Equal(t, []handlers.IDMapperResponse{{ID: "usd", Name: "dollar"}}, page.Items)
+	page = list("/v1/currency?name_prefix=E")
+	assert.Equal(t, []handlers.IDMapperResponse{{ID: "eur", Name: "euro"}}, page.Items)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/country?format=ndjson", nil)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, "application/x-ndjson", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"id\":\"sk\",\"name\":\"Slovakia\"}\n{\"id\":\"us\",\"name\":\"USA\"}\n", resp.Body.String())
+
+	for _, url := range []string{"/v1/country?sort=size", "/v1/country?limit=0", "/v1/country?cursor=invalid"} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.Nil(t, err)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Code, url)
+	}
+}
+
+func TestAppListStableAcrossReloads(t *testing.T) {
+	values := idmapper.ValuesMap{"a": "A", "b": "B", "c": "C"}
+	idMapper, err := idmapper.NewIDMapper(idmapper.SourceReaderFunc(func() (idmapper.ValuesMap, error) {
+		return values, nil
+	}))
+	assert.Nil(t, err)
+
+	h := handlers.NewListHandler("language", idMapper, 0)
+	list := func(url string) (int, handlers.ListResponse) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.Nil(t, err)
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+
+		var listResponse handlers.ListResponse
+		_ = json.Unmarshal(resp.Body.Bytes(), &listResponse)
+		return resp.Code, listResponse
+	}
+
+	_, first := list("/?limit=2")
+	assert.Equal(t, []handlers.IDMapperResponse{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}}, first.Items)
+
+	// listing continues from snapshot it was started from
+	values = idmapper.ValuesMap{"a": "A", "aa": "AA", "c": "CC"}
+	assert.Nil(t, idMapper.Reload())
+	_, second := list("/?limit=2&cursor=" + first.NextCursor)
+	assert.Equal(t, []handlers.IDMapperResponse{{ID: "c", Name: "C"}}, second.Items)
+	assert.Equal(t, first.Version, second.Version)
+
+	// old snapshots expire after more reloads
+	for _, name := range []string{"D", "E", "F", "G"} {
+		values = idmapper.ValuesMap{"d": name}
+		assert.Nil(t, idMapper.Reload())
+		list("/")
+	}
+	code, _ := list("/?limit=2&cursor=" + first.NextCursor)
+	assert.Equal(t, http.StatusGone, code)
+}
+
+func TestAppSearch(t *testing.T) {
+	testApp, err := NewTestApp()
+	assert.Nil(t, err)
+	defer testApp.Close()
+
+	router := app.CreateRouter("/v1", nil, testApp.App.IDMappers, 0, app.CacheConfig{}, app.ReadinessConfig{})
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/language/_search?q=slov", nil)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var searchResponse handlers.SearchResponse
+	assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &searchResponse))
+	assert.Equal(t, []handlers.SearchResult{{ID: "sk", Name: "Slovak", Score: idmapper.PrefixScore}}, searchResponse.Results)
+
+	req, err = http.NewRequest(http.MethodGet, "/v1/language/_search?q=slov&limit=1000", nil)
+	assert.Nil(t, err)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
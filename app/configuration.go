@@ -3,6 +3,7 @@ package app
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -12,12 +13,54 @@ import (
 
 // Configuration application configuration
 type Configuration struct {
-	Addr       string           `mapstructure:"addr"`
-	APIPrefix  string           `mapstructure:"api_prefix"`
+	Addr      string `mapstructure:"addr"`
+	APIPrefix string `mapstructure:"api_prefix"`
+	// AdminAddr is http address of admin API, loopback by default. Admin API is served on Addr if it is explicitly set empty
+	AdminAddr  string           `mapstructure:"admin_addr"`
 	Logger     LoggerConfig     `mapstructure:"logger"`
 	Redis      RedisConfig      `mapstructure:"redis"`
 	PostgreSQL PostgreSQLConfig `mapstructure:"postgresql"`
 	IDMappers  idmappers.Config `mapstructure:"idmappers"`
+	// CacheMaxAge is max-age of cached responses of IDMappers
+	CacheMaxAge CacheConfig `mapstructure:"cache_max_age"`
+	// Readiness configures readiness check
+	Readiness ReadinessConfig `mapstructure:"readiness"`
+	// MaxBatchSize is maximal number of IDs in single bulk lookup, not limited if zero
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+	// ShutdownTimeout is maximal duration of graceful shutdown of http server and IDMappers reloading
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+// CacheConfig configures max-age of cached responses of each IDMapper, responses must be revalidated if max-age is zero
+type CacheConfig struct {
+	Currency time.Duration `mapstructure:"currency"`
+	Country  time.Duration `mapstructure:"country"`
+	Language time.Duration `mapstructure:"language"`
+}
+
+// MaxAge returns max-age of cached responses of IDMapper with given name
+func (config CacheConfig) MaxAge(name string) time.Duration {
+	switch name {
+	case "currency":
+		return config.Currency
+	case "country":
+		return config.Country
+	case "language":
+		return config.Language
+	}
+	return 0
+}
+
+// ReadinessConfig configures readiness check of application
+type ReadinessConfig struct {
+	// Critical are names of IDMappers required for readiness, all IDMappers are required if empty
+	Critical []string `mapstructure:"critical"`
+	// MaxDataAge is maximal time since last successful load of critical IDMapper, not limited if zero
+	MaxDataAge time.Duration `mapstructure:"max_data_age"`
+	// BackendInterval is duration between periodic checks of backends
+	BackendInterval time.Duration `mapstructure:"backend_interval"`
+	// BackendTimeout is timeout of checks of backends
+	BackendTimeout time.Duration `mapstructure:"backend_timeout"`
 }
 
 // LoggerConfig application configuration for Logger
@@ -51,15 +94,45 @@ func readConfiguration(configFile string) (*Configuration, error) {
 
 	viper.SetDefault("addr", "0.0.0.0:80")
 	viper.SetDefault("api_prefix", "/v1")
+	viper.SetDefault("admin_addr", "127.0.0.1:8082")
+	viper.SetDefault("max_batch_size", 1000)
+	viper.SetDefault("cache_max_age.currency", "1h")
+	viper.SetDefault("cache_max_age.country", "1h")
+	viper.SetDefault("cache_max_age.language", "1h")
+	viper.SetDefault("readiness.critical", []string{})
+	viper.SetDefault("readiness.max_data_age", "0s")
+	viper.SetDefault("readiness.backend_interval", "10s")
+	viper.SetDefault("readiness.backend_timeout", "2s")
+	viper.SetDefault("shutdown_timeout", "25s")
 	viper.SetDefault("logger.json", false)
 	viper.SetDefault("redis.addr", "localhost:6379")
 	viper.SetDefault("redis.password", "")
 	viper.SetDefault("postgresql.connection_string", "postgresql://localhost")
+	viper.SetDefault("idmappers.reloader.workers", 2)
+	viper.SetDefault("idmappers.reloader.state.store", "none")
+	viper.SetDefault("idmappers.reloader.state.file", "idmapper-state.json")
+	viper.SetDefault("idmappers.reloader.state.redis_key", "idmapper:reloader:state")
 	viper.SetDefault("idmappers.reloader.currency.interval", "24h")
 	viper.SetDefault("idmappers.reloader.currency.redis_hash_name", "currency-codes")
 	viper.SetDefault("idmappers.reloader.country.interval", "24h")
 	viper.SetDefault("idmappers.reloader.language.interval", "24h")
+	for _, mapper := range []string{"currency", "country", "language"} {
+		viper.SetDefault(fmt.Sprintf("idmappers.reloader.%s.jitter", mapper), "1m")
+		viper.SetDefault(fmt.Sprintf("idmappers.reloader.%s.overlap", mapper), "skip")
+		viper.SetDefault(fmt.Sprintf("idmappers.reloader.%s.catch_up", mapper), "once")
+		viper.SetDefault(fmt.Sprintf("idmappers.reloader.%s.timeout", mapper), "1m")
+		viper.SetDefault(fmt.Sprintf("idmappers.reloader.%s.retry.initial_backoff", mapper), "1m")
+		viper.SetDefault(fmt.Sprintf("idmappers.reloader.%s.retry.max_backoff", mapper), "15m")
+		viper.SetDefault(fmt.Sprintf("idmappers.reloader.%s.adaptive.min_interval", mapper), "1h")
+		viper.SetDefault(fmt.Sprintf("idmappers.reloader.%s.adaptive.max_interval", mapper), "168h")
+	}
+	viper.SetDefault("idmappers.leader.key", "idmapper:leader")
+	viper.SetDefault("idmappers.leader.ttl", "30s")
+	viper.SetDefault("idmappers.leader.renew_interval", "10s")
 	viper.SetDefault("idmappers.loader.timeout", "5s")
+	viper.SetDefault("idmappers.loader.builtin.currency", "none")
+	viper.SetDefault("idmappers.loader.builtin.country", "none")
+	viper.SetDefault("idmappers.loader.builtin.language", "none")
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/danielkraic/idmapper/idmapper"
+)
+
+const (
+	// maxEncodedIDLength is maximal length of valid ID in JSON request body, each byte of ID may be escaped as \u00XX
+	maxEncodedIDLength = 6*maxIDLength + len(`"",`)
+	// bulkBodyOverhead is size of request body without IDs, with reserve for white space
+	bulkBodyOverhead = 1024
+)
+
+// BulkRequest is request body of bulk lookup
+type BulkRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// BulkResponse is response of bulk lookup, it consists of found values and requested IDs that were not found
+type BulkResponse struct {
+	Found   []IDMapperResponse `json:"found" xml:"found>entry"`
+	Missing []string           `json:"missing" xml:"missing>id"`
+}
+
+type bulkHandler struct {
+	name         string
+	idMapper     *idmapper.IDMapper
+	maxBatchSize int
+	maxAge       time.Duration
+}
+
+// NewBulkHandler creates new http.Handler looking up many IDs in one request. IDs are read from JSON body
+// of POST request or from repeated id query parameters of GET request. Batch size is not limited if maxBatchSize is zero,
+// otherwise size of request body is limited to fit maxBatchSize IDs. Responses of GET requests may be cached for maxAge
+func NewBulkHandler(name string, idMapper *idmapper.IDMapper, maxBatchSize int, maxAge time.Duration) http.Handler {
+	return &bulkHandler{
+		name:         name,
+		idMapper:     idMapper,
+		maxBatchSize: maxBatchSize,
+		maxAge:       maxAge,
+	}
+}
+
+// ServeHTTP servers http requests
+func (h *bulkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format := negotiate(w, r, formats)
+	if format == nil {
+		return
+	}
+
+	if !checkLoaded(w, r, h.name, h.idMapper) {
+		return
+	}
+
+	var request BulkRequest
+	if r.Method == http.MethodPost {
+		body := r.Body
+		maxBodySize := int64(h.maxBatchSize*maxEncodedIDLength + bulkBodyOverhead)
+		if h.maxBatchSize > 0 {
+			body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		}
+
+		err := json.NewDecoder(body).Decode(&request)
+		if errors.As(err, new(*http.MaxBytesError)) {
+			writeProblem(w, r, problemTooLarge, Problem{Mapper: h.name, Detail: fmt.Sprintf("request body exceeds %d bytes", maxBodySize)})
+			return
+		}
+		if err != nil {
+			writeProblem(w, r, problemBadRequest, Problem{Mapper: h.name, Detail: fmt.Sprintf("invalid request body: %s", err)})
+			return
+		}
+	} else {
+		request.IDs = r.URL.Query()["id"]
+	}
+
+	if h.maxBatchSize > 0 && len(request.IDs) > h.maxBatchSize {
+		writeProblem(w, r, problemBadRequest, Problem{Mapper: h.name, Detail: fmt.Sprintf("too many IDs: %d, maximum is %d", len(request.IDs), h.maxBatchSize)})
+		return
+	}
+
+	for _, id := range request.IDs {
+		if !validID(id) {
+			writeProblem(w, r, problemInvalidID, Problem{Mapper: h.name, ID: id, Detail: "ID must be non-empty printable text"})
+			return
+		}
+	}
+
+	found, missing := h.idMapper.GetMany(request.IDs)
+
+	response := BulkResponse{
+		Found:   make([]IDMapperResponse, 0, len(found)),
+		Missing: make([]string, 0, len(missing)),
+	}
+	for id, name := range found {
+		response.Found = append(response.Found, IDMapperResponse{ID: id, Name: name})
+	}
+	sort.Slice(response.Found, func(i, j int) bool {
+		return response.Found[i].ID < response.Found[j].ID
+	})
+	response.Missing = append(response.Missing, missing...)
+
+	if r.Method == http.MethodGet {
+		parts := make([]string, 0, 2*len(response.Found)+len(response.Missing)+2)
+		parts = append(parts, format.name)
+		for _, entry := range response.Found {
+			parts = append(parts, entry.ID, entry.Name)
+		}
+		// separates found values from missing IDs
+		parts = append(parts, "")
+		parts = append(parts, response.Missing...)
+
+		if notModified(w, r, h.maxAge, entityTag(parts...), h.idMapper.ModifiedAt()) {
+			return
+		}
+	}
+
+	writeResponse(w, r, format, "bulk", response)
+}
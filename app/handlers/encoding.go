@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// tabular is response which can be encoded as CSV table, as lines of plain text and as MessagePack
+type tabular interface {
+	// table returns header and rows of CSV table
+	table() ([]string, [][]string)
+	// lines returns lines of plain text, names of values
+	lines() []string
+	// msgpack writes response as MessagePack map with the same fields as JSON object
+	msgpack(writer *msgpackWriter)
+}
+
+// format is representation of responses
+type format struct {
+	// name is value of query parameter format selecting the format
+	name string
+	// mediaTypes are media types of the format in Accept header, first one is used as Content-Type of response
+	mediaTypes []string
+	// encode writes response with given XML root element name
+	encode func(w io.Writer, root string, response tabular) error
+}
+
+// Formats of responses, in order of preference if client accepts more of them equally
+var (
+	jsonFormat = &format{
+		name:       "json",
+		mediaTypes: []string{"application/json"},
+		encode: func(w io.Writer, root string, response tabular) error {
+			return json.NewEncoder(w).Encode(response)
+		},
+	}
+	xmlFormat = &format{
+		name:       "xml",
+		mediaTypes: []string{"application/xml", "text/xml"},
+		encode: func(w io.Writer, root string, response tabular) error {
+			_, err := io.WriteString(w, xml.Header)
+			if err != nil {
+				return err
+			}
+			return xml.NewEncoder(w).EncodeElement(response, xml.StartElement{Name: xml.Name{Local: root}})
+		},
+	}
+	msgpackFormat = &format{
+		name:       "msgpack",
+		mediaTypes: []string{"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"},
+		encode: func(w io.Writer, root string, response tabular) error {
+			return encodeMsgpack(w, response)
+		},
+	}
+	csvFormat = &format{
+		name:       "csv",
+		mediaTypes: []string{"text/csv"},
+		encode: func(w io.Writer, root string, response tabular) error {
+			header, rows := response.table()
+			writer := csv.NewWriter(w)
+			err := writer.Write(header)
+			if err != nil {
+				return err
+			}
+			return writer.WriteAll(rows)
+		},
+	}
+	textFormat = &format{
+		name:       "text",
+		mediaTypes: []string{"text/plain"},
+		encode: func(w io.Writer, root string, response tabular) error {
+			for _, line := range response.lines() {
+				_, err := fmt.Fprintln(w, line)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	// ndjsonFormat streams entries of list, it is encoded by list handler
+	ndjsonFormat = &format{
+		name:       "ndjson",
+		mediaTypes: []string{ndjsonContentType},
+	}
+
+	// formats are formats supported by all handlers of IDMapper values
+	formats = []*format{jsonFormat, xmlFormat, msgpackFormat, csvFormat, textFormat}
+)
+
+// contentType returns Content-Type of responses in the format
+func (f *format) contentType() string {
+	if strings.HasPrefix(f.mediaTypes[0], "text/") {
+		return f.mediaTypes[0] + "; charset=utf-8"
+	}
+	return f.mediaTypes[0]
+}
+
+// negotiate selects format of response from supported formats by query parameter format or by Accept header of request.
+// JSON is used if neither is set. If no supported format is acceptable, 406 Not Acceptable is written and nil is returned
+func negotiate(w http.ResponseWriter, r *http.Request, supported []*format) *format {
+	w.Header().Add("Vary", "Accept")
+
+	if name := r.URL.Query().Get("format"); name != "" {
+		for _, f := range supported {
+			if f.name == name {
+				return f
+			}
+		}
+		notAcceptable(w, r, supported)
+		return nil
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return supported[0]
+	}
+
+	var best *format
+	bestQuality := 0.0
+	for _, f := range supported {
+		if quality := f.quality(accept); quality > bestQuality {
+			best, bestQuality = f, quality
+		}
+	}
+	if best == nil {
+		notAcceptable(w, r, supported)
+	}
+	return best
+}
+
+// quality returns quality of the format according to the most specific matching media range of Accept header, 0 if not acceptable
+func (f *format) quality(accept string) float64 {
+	quality, specificity := 0.0, 0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, found := params["q"]; found {
+			q, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		for _, supported := range f.mediaTypes {
+			s := matchSpecificity(mediaType, supported)
+			if s > specificity {
+				quality, specificity = q, s
+			}
+		}
+	}
+	return quality
+}
+
+// matchSpecificity returns specificity of media range matching media type: 3 for exact match, 2 for type/*, 1 for */*, 0 if not matching
+func matchSpecificity(mediaRange string, mediaType string) int {
+	switch {
+	case mediaRange == mediaType:
+		return 3
+	case mediaRange == "*/*":
+		return 1
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*")):
+		return 2
+	}
+	return 0
+}
+
+func notAcceptable(w http.ResponseWriter, r *http.Request, supported []*format) {
+	mediaTypes := []string{}
+	for _, f := range supported {
+		mediaTypes = append(mediaTypes, f.mediaTypes...)
+	}
+	writeProblem(w, r, problemNotAcceptable, Problem{Detail: fmt.Sprintf("supported media types are: %s", strings.Join(mediaTypes, ", "))})
+}
+
+// writeResponse writes response in given format. Response is encoded before it is written,
+// so internal-server-error problem is written if encoding fails
+func writeResponse(w http.ResponseWriter, r *http.Request, f *format, root string, response tabular) {
+	var buf bytes.Buffer
+	err := f.encode(&buf, root, response)
+	if err != nil {
+		writeProblem(w, r, problemInternal, Problem{Detail: fmt.Sprintf("failed to encode response: %s", err)})
+		return
+	}
+
+	w.Header().Set("Content-Type", f.contentType())
+	_, _ = buf.WriteTo(w)
+}
+
+// writeJSON writes JSON response with given status. Response is encoded before it is written,
+// so internal-server-error problem is written if encoding fails
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, response interface{}) {
+	var buf bytes.Buffer
+	err := json.NewEncoder(&buf).Encode(response)
+	if err != nil {
+		writeProblem(w, r, problemInternal, Problem{Detail: fmt.Sprintf("failed to encode response: %s", err)})
+		return
+	}
+
+	w.Header().Set("Content-Type", jsonFormat.contentType())
+	w.WriteHeader(status)
+	_, _ = buf.WriteTo(w)
+}
+
+func (response IDMapperResponse) table() ([]string, [][]string) {
+	return []string{"id", "name"}, [][]string{{response.ID, response.Name}}
+}
+
+func (response IDMapperResponse) lines() []string {
+	return []string{response.Name}
+}
+
+func (response BulkResponse) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(response.Found)+len(response.Missing))
+	for _, entry := range response.Found {
+		rows = append(rows, []string{entry.ID, entry.Name, "true"})
+	}
+	for _, id := range response.Missing {
+		rows = append(rows, []string{id, "", "false"})
+	}
+	return []string{"id", "name", "found"}, rows
+}
+
+func (response BulkResponse) lines() []string {
+	return entryNames(response.Found)
+}
+
+func (response ListResponse) table() ([]string, [][]string) {
+	return []string{"id", "name"}, entryRows(response.Items)
+}
+
+func (response ListResponse) lines() []string {
+	return entryNames(response.Items)
+}
+
+func (response SearchResponse) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(response.Results))
+	for _, result := range response.Results {
+		rows = append(rows, []string{result.ID, result.Name, strconv.FormatFloat(result.Score, 'f', -1, 64)})
+	}
+	return []string{"id", "name", "score"}, rows
+}
+
+func (response SearchResponse) lines() []string {
+	names := make([]string, 0, len(response.Results))
+	for _, result := range response.Results {
+		names = append(names, result.Name)
+	}
+	return names
+}
+
+func entryRows(entries []IDMapperResponse) [][]string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{entry.ID, entry.Name})
+	}
+	return rows
+}
+
+func entryNames(entries []IDMapperResponse) []string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	return names
+}
@@ -1,8 +1,148 @@
 package handlers
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+
+	"github.com/danielkraic/idmapper/app/idmappers"
+	"github.com/danielkraic/idmapper/clock"
+)
+
+// Statuses of readiness, IDMappers and backends
+const (
+	statusOK        = "ok"
+	statusReady     = "ready"
+	statusNotReady  = "not_ready"
+	statusNotLoaded = "not_loaded"
+	statusStale     = "stale"
+	statusError     = "error"
+)
 
 // HealthHandlerFunc handles /health and return http 200 status code
 func HealthHandlerFunc(w http.ResponseWriter, req *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
+
+// LivenessResponse is response of liveness check
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+// LivenessHandlerFunc handles /livez, it returns http 200 status code while process is able to serve requests
+func LivenessHandlerFunc(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, http.StatusOK, LivenessResponse{Status: statusOK})
+}
+
+// HealthChecker provides state of IDMappers and their backends
+type HealthChecker interface {
+	Mappers() []idmappers.MapperStatus
+	// Backends returns state of backends found by last periodic check
+	Backends() []idmappers.BackendStatus
+}
+
+// ReadinessResponse is response of readiness check
+type ReadinessResponse struct {
+	// Status is ready or not_ready
+	Status   string          `json:"status"`
+	Mappers  []MapperHealth  `json:"mappers"`
+	Backends []BackendHealth `json:"backends"`
+}
+
+// MapperHealth is state of IDMapper in readiness check
+type MapperHealth struct {
+	Name string `json:"name"`
+	// Status is ok, not_loaded or stale
+	Status string `json:"status"`
+	// Critical is true if readiness requires IDMapper to be ok
+	Critical  bool       `json:"critical"`
+	Size      int        `json:"size"`
+	LoadedAt  *time.Time `json:"loaded_at,omitempty"`
+	Age       string     `json:"age,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// BackendHealth is state of backend in readiness check
+type BackendHealth struct {
+	Name string `json:"name"`
+	// Status is ok or error
+	Status    string     `json:"status"`
+	Error     string     `json:"error,omitempty"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+}
+
+type readinessHandler struct {
+	checker    HealthChecker
+	clock      clock.Clock
+	critical   map[string]bool
+	maxDataAge time.Duration
+}
+
+// NewReadinessHandler creates handler of /readyz. Instance is ready if each critical IDMapper (all IDMappers
+// if critical is empty) was loaded and its data are not older than maxDataAge (not limited if zero), age of data
+// is measured by clock. State of backends found by last check is reported but does not affect readiness
+func NewReadinessHandler(checker HealthChecker, clock clock.Clock, critical []string, maxDataAge time.Duration) http.Handler {
+	h := &readinessHandler{
+		checker:    checker,
+		clock:      clock,
+		maxDataAge: maxDataAge,
+	}
+	if len(critical) > 0 {
+		h.critical = make(map[string]bool, len(critical))
+		for _, name := range critical {
+			h.critical[name] = true
+		}
+	}
+	return h
+}
+
+// ServeHTTP serves http request
+func (h *readinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := ReadinessResponse{
+		Status:   statusReady,
+		Mappers:  []MapperHealth{},
+		Backends: []BackendHealth{},
+	}
+
+	now := h.clock.Now()
+	for _, mapper := range h.checker.Mappers() {
+		health := MapperHealth{
+			Name:      mapper.Name,
+			Status:    statusOK,
+			Critical:  h.critical == nil || h.critical[mapper.Name],
+			Size:      mapper.Size,
+			LoadedAt:  optionalTime(mapper.LoadedAt),
+			LastError: mapper.LastError,
+		}
+
+		if mapper.LoadedAt.IsZero() {
+			health.Status = statusNotLoaded
+		} else {
+			age := now.Sub(mapper.LoadedAt)
+			health.Age = age.Round(time.Second).String()
+			if h.maxDataAge > 0 && age > h.maxDataAge {
+				health.Status = statusStale
+			}
+		}
+
+		if health.Critical && health.Status != statusOK {
+			response.Status = statusNotReady
+		}
+		response.Mappers = append(response.Mappers, health)
+	}
+
+	for _, backend := range h.checker.Backends() {
+		health := BackendHealth{Name: backend.Name, Status: statusOK, CheckedAt: optionalTime(backend.CheckedAt)}
+		if backend.Error != "" {
+			health.Status = statusError
+			health.Error = backend.Error
+		}
+		response.Backends = append(response.Backends, health)
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	status := http.StatusOK
+	if response.Status != statusReady {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, r, status, response)
+}
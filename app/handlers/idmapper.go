@@ -1,8 +1,9 @@
 package handlers
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/danielkraic/idmapper/idmapper"
 	"github.com/gorilla/mux"
@@ -10,29 +11,51 @@ import (
 
 // IDMapperResponse response struct consists of ID and Name
 type IDMapperResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID   string `json:"id" xml:"id"`
+	Name string `json:"name" xml:"name"`
 }
 
 type idMapperHandler struct {
+	name     string
 	idMapper *idmapper.IDMapper
+	maxAge   time.Duration
 }
 
-// NewIDMapperHandler creates new http.Handler with IDMapper
-func NewIDMapperHandler(idMapper *idmapper.IDMapper) http.Handler {
+// NewIDMapperHandler creates new http.Handler with IDMapper of given name. Responses may be cached for maxAge,
+// they must be revalidated using ETag or Last-Modified if maxAge is zero
+func NewIDMapperHandler(name string, idMapper *idmapper.IDMapper, maxAge time.Duration) http.Handler {
 	return &idMapperHandler{
+		name:     name,
 		idMapper: idMapper,
+		maxAge:   maxAge,
 	}
 }
 
 // ServeHTTP servers http requuests
 func (h *idMapperHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format := negotiate(w, r, formats)
+	if format == nil {
+		return
+	}
+
+	if !checkLoaded(w, r, h.name, h.idMapper) {
+		return
+	}
+
 	vars := mux.Vars(r)
-	id := vars["id"]
-	name, found := h.idMapper.Get(id)
+	requested := vars["id"]
+	if !validID(requested) {
+		writeProblem(w, r, problemInvalidID, Problem{Mapper: h.name, ID: requested, Detail: "ID must be non-empty printable text"})
+		return
+	}
 
+	id, name, found := h.idMapper.Lookup(requested)
 	if !found {
-		w.WriteHeader(http.StatusNotFound)
+		writeProblem(w, r, problemUnknownID, Problem{Mapper: h.name, ID: requested, Detail: fmt.Sprintf("ID %s not found in mapper %s", requested, h.name)})
+		return
+	}
+
+	if notModified(w, r, h.maxAge, entityTag(format.name, id, name), h.idMapper.ModifiedAt()) {
 		return
 	}
 
@@ -40,8 +63,5 @@ func (h *idMapperHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ID:   id,
 		Name: name,
 	}
-	err := json.NewEncoder(w).Encode(response)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-	}
+	writeResponse(w, r, format, "entry", response)
 }
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielkraic/idmapper/scheduler"
+	"github.com/gorilla/mux"
+)
+
+// JobsLister provides status of scheduled jobs
+type JobsLister interface {
+	Jobs() []scheduler.JobStatus
+}
+
+// JobResponse is status of scheduled job
+type JobResponse struct {
+	Name         string     `json:"name"`
+	Running      bool       `json:"running"`
+	Paused       bool       `json:"paused"`
+	LastStart    *time.Time `json:"last_start,omitempty"`
+	LastFinish   *time.Time `json:"last_finish,omitempty"`
+	LastDuration string     `json:"last_duration,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	Successes    uint64     `json:"successes"`
+	Failures     uint64     `json:"failures"`
+	NextRun      *time.Time `json:"next_run,omitempty"`
+}
+
+// JobAction is action performed on scheduled job with given name
+type JobAction func(name string) error
+
+type jobsHandler struct {
+	jobs JobsLister
+}
+
+// NewJobsHandler creates handler listing scheduled jobs
+func NewJobsHandler(jobs JobsLister) http.Handler {
+	return &jobsHandler{
+		jobs: jobs,
+	}
+}
+
+// ServeHTTP serves http request
+func (h *jobsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := []JobResponse{}
+	for _, job := range h.jobs.Jobs() {
+		response = append(response, newJobResponse(job))
+	}
+
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+func newJobResponse(job scheduler.JobStatus) JobResponse {
+	response := JobResponse{
+		Name:       job.Name,
+		Running:    job.Running,
+		Paused:     job.Paused,
+		LastStart:  optionalTime(job.LastStart),
+		LastFinish: optionalTime(job.LastFinish),
+		LastError:  job.LastError,
+		Successes:  job.Successes,
+		Failures:   job.Failures,
+		NextRun:    optionalTime(job.NextRun),
+	}
+	if !job.LastFinish.IsZero() {
+		response.LastDuration = job.LastDuration.String()
+	}
+
+	return response
+}
+
+type jobActionHandler struct {
+	jobs   JobsLister
+	action JobAction
+}
+
+// NewJobActionHandler creates handler performing action on scheduled job
+func NewJobActionHandler(jobs JobsLister, action JobAction) http.Handler {
+	return &jobActionHandler{
+		jobs:   jobs,
+		action: action,
+	}
+}
+
+// ServeHTTP serves http request
+func (h *jobActionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	found := false
+	for _, job := range h.jobs.Jobs() {
+		if job.Name == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		writeProblem(w, r, problemNotFound, Problem{Detail: fmt.Sprintf("job %s not found", name)})
+		return
+	}
+
+	err := h.action(name)
+	if err != nil {
+		writeProblem(w, r, problemConflict, Problem{Detail: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielkraic/idmapper/idmapper"
+)
+
+const (
+	// defaultListLimit is number of entries in page if limit is not requested
+	defaultListLimit = 100
+	// maxListLimit is maximal number of entries in page
+	maxListLimit = 1000
+	// listSnapshots is number of recent sorted snapshots kept, so listings started before reload can be finished
+	listSnapshots = 4
+	// ndjsonContentType is content type of streamed list
+	ndjsonContentType = "application/x-ndjson"
+)
+
+// ListResponse is page of entries of IDMapper
+type ListResponse struct {
+	Items []IDMapperResponse `json:"items" xml:"items>entry"`
+	// Version is version of snapshot of IDMapper values the page was read from
+	Version string `json:"version" xml:"version"`
+	// NextCursor is cursor of next page, empty if there are no more entries
+	NextCursor string `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+}
+
+// listFormats are formats of list, ndjson streams all remaining entries
+var listFormats = append(formats[:len(formats):len(formats)], ndjsonFormat)
+
+// listCursor is position in listing, encoded as base64 JSON
+type listCursor struct {
+	Version string `json:"v"`
+	Sort    string `json:"s"`
+	ID      string `json:"i"`
+	Name    string `json:"n"`
+}
+
+// sortedSnapshot is snapshot of IDMapper values sorted by id or name
+type sortedSnapshot struct {
+	version  string
+	sort     string
+	modified time.Time
+	entries  []IDMapperResponse
+}
+
+type listHandler struct {
+	name      string
+	idMapper  *idmapper.IDMapper
+	maxAge    time.Duration
+	mtx       sync.Mutex
+	snapshots []*sortedSnapshot
+}
+
+// NewListHandler creates new http.Handler listing entries of IDMapper of given name. Query parameters are:
+// sort (id or name), id_prefix, name_prefix (case-insensitive), limit, cursor (next_cursor of previous page)
+// and format (json, xml, msgpack, csv, text or ndjson which streams all remaining entries). Pages of listing
+// are read from the same snapshot of values also if IDMapper is reloaded, unless snapshot is too old.
+// Responses may be cached for maxAge
+func NewListHandler(name string, idMapper *idmapper.IDMapper, maxAge time.Duration) http.Handler {
+	return &listHandler{
+		name:     name,
+		idMapper: idMapper,
+		maxAge:   maxAge,
+	}
+}
+
+// ServeHTTP servers http requests
+func (h *listHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format := negotiate(w, r, listFormats)
+	if format == nil {
+		return
+	}
+
+	if !checkLoaded(w, r, h.name, h.idMapper) {
+		return
+	}
+
+	query := r.URL.Query()
+
+	sortBy := query.Get("sort")
+	if sortBy == "" {
+		sortBy = "id"
+	}
+	if sortBy != "id" && sortBy != "name" {
+		writeProblem(w, r, problemBadRequest, Problem{Mapper: h.name, Detail: fmt.Sprintf("invalid sort '%s', use id or name", sortBy)})
+		return
+	}
+
+	limit := defaultListLimit
+	if value := query.Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxListLimit {
+			writeProblem(w, r, problemBadRequest, Problem{Mapper: h.name, Detail: fmt.Sprintf("invalid limit '%s', maximum is %d", value, maxListLimit)})
+			return
+		}
+	}
+
+	version := ""
+	var cursor *listCursor
+	if value := query.Get("cursor"); value != "" {
+		var err error
+		cursor, err = decodeListCursor(value)
+		if err != nil || cursor.Sort != sortBy {
+			writeProblem(w, r, problemBadRequest, Problem{Mapper: h.name, Detail: "invalid cursor"})
+			return
+		}
+		version = cursor.Version
+	}
+
+	snapshot := h.sorted(version, sortBy)
+	if snapshot == nil {
+		writeProblem(w, r, problemSnapshotExpired, Problem{Mapper: h.name, Detail: "snapshot of cursor expired, restart listing"})
+		return
+	}
+
+	start := 0
+	if cursor != nil {
+		start = sort.Search(len(snapshot.entries), func(i int) bool {
+			return snapshot.after(snapshot.entries[i], cursor)
+		})
+	}
+
+	idPrefix := query.Get("id_prefix")
+	namePrefix := strings.ToLower(query.Get("name_prefix"))
+	matches := func(entry IDMapperResponse) bool {
+		return strings.HasPrefix(entry.ID, idPrefix) && strings.HasPrefix(strings.ToLower(entry.Name), namePrefix)
+	}
+
+	w.Header().Set("X-Snapshot-Version", snapshot.version)
+
+	if notModified(w, r, h.maxAge, entityTag(snapshot.version, format.name), snapshot.modified) {
+		return
+	}
+
+	if format == ndjsonFormat {
+		w.Header().Set("Content-Type", format.contentType())
+		encoder := json.NewEncoder(w)
+		for _, entry := range snapshot.entries[start:] {
+			if !matches(entry) {
+				continue
+			}
+			if encoder.Encode(entry) != nil {
+				return
+			}
+		}
+		return
+	}
+
+	response := ListResponse{
+		Items:   make([]IDMapperResponse, 0, limit),
+		Version: snapshot.version,
+	}
+	for _, entry := range snapshot.entries[start:] {
+		if !matches(entry) {
+			continue
+		}
+		if len(response.Items) == limit {
+			last := response.Items[limit-1]
+			response.NextCursor = encodeListCursor(listCursor{Version: snapshot.version, Sort: sortBy, ID: last.ID, Name: last.Name})
+			break
+		}
+		response.Items = append(response.Items, entry)
+	}
+
+	if response.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", response.NextCursor)
+	}
+	writeResponse(w, r, format, "list", response)
+}
+
+// sorted returns sorted snapshot of given version, current version is used if version is empty.
+// Older versions are looked up in recent snapshots. Returns nil if snapshot of version is not available
+func (h *listHandler) sorted(version string, sortBy string) *sortedSnapshot {
+	values, current := h.idMapper.Snapshot()
+	if version == "" {
+		version = current
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	for _, snapshot := range h.snapshots {
+		if snapshot.version == version && snapshot.sort == sortBy {
+			return snapshot
+		}
+	}
+
+	if version != current {
+		return nil
+	}
+
+	snapshot := &sortedSnapshot{
+		version:  version,
+		sort:     sortBy,
+		modified: h.idMapper.ModifiedAt(),
+		entries:  make([]IDMapperResponse, 0, len(values)),
+	}
+	for id, name := range values {
+		snapshot.entries = append(snapshot.entries, IDMapperResponse{ID: id, Name: name})
+	}
+	sort.Slice(snapshot.entries, func(i, j int) bool {
+		return snapshot.less(snapshot.entries[i], snapshot.entries[j])
+	})
+
+	h.snapshots = append(h.snapshots, snapshot)
+	if len(h.snapshots) > listSnapshots {
+		h.snapshots = h.snapshots[1:]
+	}
+
+	return snapshot
+}
+
+func (snapshot *sortedSnapshot) less(a IDMapperResponse, b IDMapperResponse) bool {
+	if snapshot.sort == "name" && a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.ID < b.ID
+}
+
+// after returns true if entry follows position of cursor
+func (snapshot *sortedSnapshot) after(entry IDMapperResponse, cursor *listCursor) bool {
+	return snapshot.less(IDMapperResponse{ID: cursor.ID, Name: cursor.Name}, entry)
+}
+
+func encodeListCursor(cursor listCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(value string) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor listCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
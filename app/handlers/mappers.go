@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielkraic/idmapper/app/idmappers"
+	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/danielkraic/idmapper/scheduler"
+	"github.com/gorilla/mux"
+)
+
+// MappersAdmin provides state of IDMappers and reloads them
+type MappersAdmin interface {
+	Names() []string
+	Mapper(name string) (*idmapper.IDMapper, bool)
+	Mappers() []idmappers.MapperStatus
+	// Reload runs reload job of IDMapper and waits until reload finishes, scheduler.RunError is returned if reload failed
+	Reload(ctx context.Context, name string, force bool) error
+	// RunJob runs reload job of IDMapper in background
+	RunJob(name string) error
+	// ForceRunJob runs reload job of IDMapper in background, also if it is in blackout or outside of its windows
+	ForceRunJob(name string) error
+}
+
+// MapperResponse is state of IDMapper
+type MapperResponse struct {
+	Name       string     `json:"name"`
+	Size       int        `json:"size"`
+	Version    string     `json:"version"`
+	LoadedAt   *time.Time `json:"loaded_at,omitempty"`
+	LastReload *time.Time `json:"last_reload,omitempty"`
+	LastError  string     `json:"last_error,omitempty"`
+}
+
+// SnapshotResponse is dump of values of IDMapper
+type SnapshotResponse struct {
+	Name     string             `json:"name"`
+	Version  string             `json:"version"`
+	LoadedAt *time.Time         `json:"loaded_at,omitempty"`
+	Values   idmapper.ValuesMap `json:"values"`
+}
+
+type mappersHandler struct {
+	mappers MappersAdmin
+}
+
+// NewMappersHandler creates handler listing state of IDMappers
+func NewMappersHandler(mappers MappersAdmin) http.Handler {
+	return &mappersHandler{
+		mappers: mappers,
+	}
+}
+
+// ServeHTTP serves http request
+func (h *mappersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, http.StatusOK, mapperResponses(h.mappers.Mappers(), h.mappers.Names()))
+}
+
+type reloadHandler struct {
+	mappers MappersAdmin
+}
+
+// NewReloadHandler creates handler reloading IDMapper given by name route variable, or all IDMappers if name is not set.
+// Reload jobs are run and handler responds with state of reloaded IDMappers after they finish, with query parameter
+// async=true reload jobs are only started in background. With force=true jobs are run also in blackouts or outside of windows
+func NewReloadHandler(mappers MappersAdmin) http.Handler {
+	return &reloadHandler{
+		mappers: mappers,
+	}
+}
+
+// ServeHTTP serves http request
+func (h *reloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	names := h.mappers.Names()
+	if name, found := mux.Vars(r)["name"]; found {
+		if _, found := h.mappers.Mapper(name); !found {
+			writeProblem(w, r, problemUnknownMapper, Problem{Mapper: name, Detail: fmt.Sprintf("mapper %s does not exist", name)})
+			return
+		}
+		names = []string{name}
+	}
+
+	force := r.URL.Query().Get("force") == "true"
+	if r.URL.Query().Get("async") == "true" {
+		run := h.mappers.RunJob
+		if force {
+			run = h.mappers.ForceRunJob
+		}
+		for _, name := range names {
+			err := run(name)
+			if err != nil {
+				writeProblem(w, r, problemConflict, Problem{Mapper: name, Detail: err.Error()})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	status := http.StatusOK
+	for _, name := range names {
+		err := h.mappers.Reload(r.Context(), name, force)
+		if _, failed := err.(*scheduler.RunError); failed {
+			status = http.StatusInternalServerError
+		} else if err != nil {
+			writeProblem(w, r, problemConflict, Problem{Mapper: name, Detail: err.Error()})
+			return
+		}
+	}
+
+	writeJSON(w, r, status, mapperResponses(h.mappers.Mappers(), names))
+}
+
+type snapshotHandler struct {
+	mappers MappersAdmin
+}
+
+// NewSnapshotHandler creates handler dumping current values of IDMapper given by name route variable
+func NewSnapshotHandler(mappers MappersAdmin) http.Handler {
+	return &snapshotHandler{
+		mappers: mappers,
+	}
+}
+
+// ServeHTTP serves http request
+func (h *snapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	idMapper, found := h.mappers.Mapper(name)
+	if !found {
+		writeProblem(w, r, problemUnknownMapper, Problem{Mapper: name, Detail: fmt.Sprintf("mapper %s does not exist", name)})
+		return
+	}
+
+	values, version := idMapper.Snapshot()
+	response := SnapshotResponse{
+		Name:     name,
+		Version:  version,
+		LoadedAt: optionalTime(idMapper.LoadedAt()),
+		Values:   values,
+	}
+
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// mapperResponses returns responses of IDMappers with given names
+func mapperResponses(statuses []idmappers.MapperStatus, names []string) []MapperResponse {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	response := []MapperResponse{}
+	for _, status := range statuses {
+		if !wanted[status.Name] {
+			continue
+		}
+		response = append(response, MapperResponse{
+			Name:       status.Name,
+			Size:       status.Size,
+			Version:    status.Version,
+			LoadedAt:   optionalTime(status.LoadedAt),
+			LastReload: optionalTime(status.LastReload),
+			LastError:  status.LastError,
+		})
+	}
+
+	return response
+}
+
+// optionalTime returns nil for zero time, so it is omitted from response
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
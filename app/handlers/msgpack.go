@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// msgpackWriter writes values in MessagePack format using their most compact representation.
+// Writing stops at first error, it is kept in err
+type msgpackWriter struct {
+	w   io.Writer
+	err error
+	buf [9]byte
+}
+
+// encodeMsgpack writes response encoded as MessagePack with the same field names and omitted fields as in JSON responses
+func encodeMsgpack(w io.Writer, response tabular) error {
+	writer := &msgpackWriter{w: w}
+	response.msgpack(writer)
+	return writer.err
+}
+
+func (writer *msgpackWriter) write(data []byte) {
+	if writer.err == nil {
+		_, writer.err = writer.w.Write(data)
+	}
+}
+
+func (writer *msgpackWriter) writeString(s string) {
+	writer.writeHeader(len(s), 0xa0, 32, 0xd9, 0xda, 0xdb)
+	if writer.err == nil {
+		_, writer.err = io.WriteString(writer.w, s)
+	}
+}
+
+func (writer *msgpackWriter) writeFloat(f float64) {
+	writer.buf[0] = 0xcb
+	binary.BigEndian.PutUint64(writer.buf[1:], math.Float64bits(f))
+	writer.write(writer.buf[:9])
+}
+
+func (writer *msgpackWriter) writeArrayHeader(length int) {
+	writer.writeHeader(length, 0x90, 16, 0, 0xdc, 0xdd)
+}
+
+func (writer *msgpackWriter) writeMapHeader(length int) {
+	writer.writeHeader(length, 0x80, 16, 0, 0xde, 0xdf)
+}
+
+// writeHeader writes type and length of string, array or map. Fix type is used for lengths below fixLimit,
+// otherwise 8 bit (if type8 is set), 16 bit or 32 bit length follows the type
+func (writer *msgpackWriter) writeHeader(length int, fixType byte, fixLimit int, type8 byte, type16 byte, type32 byte) {
+	switch {
+	case length < fixLimit:
+		writer.buf[0] = fixType | byte(length)
+		writer.write(writer.buf[:1])
+	case type8 != 0 && length <= math.MaxUint8:
+		writer.buf[0], writer.buf[1] = type8, byte(length)
+		writer.write(writer.buf[:2])
+	case length <= math.MaxUint16:
+		writer.buf[0] = type16
+		binary.BigEndian.PutUint16(writer.buf[1:], uint16(length))
+		writer.write(writer.buf[:3])
+	default:
+		writer.buf[0] = type32
+		binary.BigEndian.PutUint32(writer.buf[1:], uint32(length))
+		writer.write(writer.buf[:5])
+	}
+}
+
+func (response IDMapperResponse) msgpack(writer *msgpackWriter) {
+	writer.writeMapHeader(2)
+	writer.writeString("id")
+	writer.writeString(response.ID)
+	writer.writeString("name")
+	writer.writeString(response.Name)
+}
+
+func (response BulkResponse) msgpack(writer *msgpackWriter) {
+	writer.writeMapHeader(2)
+	writer.writeString("found")
+	writer.writeArrayHeader(len(response.Found))
+	for _, entry := range response.Found {
+		entry.msgpack(writer)
+	}
+	writer.writeString("missing")
+	writer.writeArrayHeader(len(response.Missing))
+	for _, id := range response.Missing {
+		writer.writeString(id)
+	}
+}
+
+func (response ListResponse) msgpack(writer *msgpackWriter) {
+	fields := 2
+	if response.NextCursor != "" {
+		fields++
+	}
+
+	writer.writeMapHeader(fields)
+	writer.writeString("items")
+	writer.writeArrayHeader(len(response.Items))
+	for _, entry := range response.Items {
+		entry.msgpack(writer)
+	}
+	writer.writeString("version")
+	writer.writeString(response.Version)
+	if response.NextCursor != "" {
+		writer.writeString("next_cursor")
+		writer.writeString(response.NextCursor)
+	}
+}
+
+func (response SearchResponse) msgpack(writer *msgpackWriter) {
+	writer.writeMapHeader(1)
+	writer.writeString("results")
+	writer.writeArrayHeader(len(response.Results))
+	for _, result := range response.Results {
+		writer.writeMapHeader(3)
+		writer.writeString("id")
+		writer.writeString(result.ID)
+		writer.writeString("name")
+		writer.writeString(result.Name)
+		writer.writeString("score")
+		writer.writeFloat(result.Score)
+	}
+}
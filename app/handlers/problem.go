@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/danielkraic/idmapper/idmapper"
+)
+
+const (
+	// problemContentType is content type of error responses
+	problemContentType = "application/problem+json"
+	// problemTypePrefix is prefix of URIs identifying types of problems
+	problemTypePrefix = "urn:idmapper:problem:"
+	// requestIDHeader is header with ID of request, it is generated if client does not set it
+	requestIDHeader = "X-Request-ID"
+	// maxRequestIDLength is maximal length of request ID set by client
+	maxRequestIDLength = 128
+	// maxIDLength is maximal length of valid ID
+	maxIDLength = 256
+)
+
+// Problem is error response according to RFC 7807
+type Problem struct {
+	// Type is URI identifying type of problem
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Instance is path of request
+	Instance string `json:"instance,omitempty"`
+	// Mapper is name of requested IDMapper
+	Mapper string `json:"mapper,omitempty"`
+	// ID is requested ID
+	ID        string `json:"id,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// problemType is type of problem with its title and http status
+type problemType struct {
+	name   string
+	title  string
+	status int
+}
+
+// Types of problems
+var (
+	problemUnknownMapper    = problemType{"unknown-mapper", "Unknown mapper", http.StatusNotFound}
+	problemUnknownID        = problemType{"unknown-id", "Unknown ID", http.StatusNotFound}
+	problemInvalidID        = problemType{"invalid-id", "Invalid ID format", http.StatusBadRequest}
+	problemNotLoaded        = problemType{"mapper-not-loaded", "Mapper is not loaded yet", http.StatusServiceUnavailable}
+	problemBadRequest       = problemType{"bad-request", "Bad request", http.StatusBadRequest}
+	problemTooLarge         = problemType{"request-too-large", "Request body too large", http.StatusRequestEntityTooLarge}
+	problemNotFound         = problemType{"not-found", "Not found", http.StatusNotFound}
+	problemMethodNotAllowed = problemType{"method-not-allowed", "Method not allowed", http.StatusMethodNotAllowed}
+	problemNotAcceptable    = problemType{"not-acceptable", "Not acceptable", http.StatusNotAcceptable}
+	problemConflict         = problemType{"conflict", "Conflict", http.StatusConflict}
+	problemSnapshotExpired  = problemType{"snapshot-expired", "Snapshot of cursor expired", http.StatusGone}
+	problemInternal         = problemType{"internal", "Internal server error", http.StatusInternalServerError}
+)
+
+// writeProblem writes error response of given type. Type, title, status, instance and request ID of problem are set
+func writeProblem(w http.ResponseWriter, r *http.Request, kind problemType, problem Problem) {
+	problem.Type = problemTypePrefix + kind.name
+	problem.Title = kind.title
+	problem.Status = kind.status
+	problem.Instance = r.URL.Path
+	problem.RequestID = requestID(w, r)
+
+	// caching headers of successful response do not apply
+	w.Header().Del("ETag")
+	w.Header().Del("Last-Modified")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(kind.status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// checkLoaded writes mapper-not-loaded problem and returns false if values of IDMapper were not loaded yet
+func checkLoaded(w http.ResponseWriter, r *http.Request, name string, idMapper *idmapper.IDMapper) bool {
+	if !idMapper.LoadedAt().IsZero() {
+		return true
+	}
+
+	writeProblem(w, r, problemNotLoaded, Problem{Mapper: name, Detail: fmt.Sprintf("values of mapper %s were not loaded yet", name)})
+	return false
+}
+
+// validID returns true if ID is not empty, not too long and consists of printable characters
+func validID(id string) bool {
+	if id == "" || len(id) > maxIDLength || !utf8.ValidString(id) {
+		return false
+	}
+	return strings.IndexFunc(id, func(r rune) bool { return !unicode.IsPrint(r) }) < 0
+}
+
+type requestIDKey struct{}
+
+// WithRequestID creates handler assigning ID to each request. ID is read from X-Request-ID header of request
+// or generated, it is returned in X-Request-ID header of response and in error responses
+func WithRequestID(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength || !validID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(requestIDHeader, id)
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// requestID returns ID of request assigned by WithRequestID. If request was not passed through WithRequestID,
+// ID is generated and set to response header
+func requestID(w http.ResponseWriter, r *http.Request) string {
+	if id, ok := r.Context().Value(requestIDKey{}).(string); ok {
+		return id
+	}
+
+	id := newRequestID()
+	w.Header().Set(requestIDHeader, id)
+	return id
+}
+
+func newRequestID() string {
+	random := make([]byte, 16)
+	_, err := rand.Read(random)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(random)
+}
+
+type notFoundHandler struct {
+	apiPrefix string
+	names     []string
+}
+
+// NewNotFoundHandler creates handler of requests not matching any route. Requests of IDMapper not in names
+// under apiPrefix get unknown-mapper problem, other requests not-found problem
+func NewNotFoundHandler(apiPrefix string, names []string) http.Handler {
+	return &notFoundHandler{
+		apiPrefix: apiPrefix,
+		names:     names,
+	}
+}
+
+// ServeHTTP serves http request
+func (h *notFoundHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.names != nil && strings.HasPrefix(r.URL.Path, h.apiPrefix+"/") {
+		mapper := strings.SplitN(strings.TrimPrefix(r.URL.Path, h.apiPrefix+"/"), "/", 2)[0]
+		known := false
+		for _, name := range h.names {
+			known = known || name == mapper
+		}
+		if !known {
+			writeProblem(w, r, problemUnknownMapper, Problem{Mapper: mapper, Detail: fmt.Sprintf("mapper %s does not exist", mapper)})
+			return
+		}
+	}
+
+	writeProblem(w, r, problemNotFound, Problem{Detail: fmt.Sprintf("path %s not found", r.URL.Path)})
+}
+
+// MethodNotAllowedHandlerFunc handles requests with method not allowed by matching route
+func MethodNotAllowedHandlerFunc(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, problemMethodNotAllowed, Problem{Detail: fmt.Sprintf("method %s is not allowed for %s", r.Method, r.URL.Path)})
+}
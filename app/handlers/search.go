@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/danielkraic/idmapper/idmapper"
+)
+
+const (
+	// defaultSearchLimit is number of search results if limit is not requested
+	defaultSearchLimit = 10
+	// maxSearchLimit is maximal number of search results
+	maxSearchLimit = 100
+)
+
+// SearchResult is value found by search with its score
+type SearchResult struct {
+	ID    string  `json:"id" xml:"id"`
+	Name  string  `json:"name" xml:"name"`
+	Score float64 `json:"score" xml:"score"`
+}
+
+// SearchResponse is response of search ordered by score of results
+type SearchResponse struct {
+	Results []SearchResult `json:"results" xml:"results>result"`
+}
+
+type searchHandler struct {
+	name     string
+	idMapper *idmapper.IDMapper
+}
+
+// NewSearchHandler creates new http.Handler searching values of IDMapper of given name by query parameter q
+// (see IDMapper.Search). Number of results is set by query parameter limit
+func NewSearchHandler(name string, idMapper *idmapper.IDMapper) http.Handler {
+	return &searchHandler{
+		name:     name,
+		idMapper: idMapper,
+	}
+}
+
+// ServeHTTP servers http requests
+func (h *searchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format := negotiate(w, r, formats)
+	if format == nil {
+		return
+	}
+
+	if !checkLoaded(w, r, h.name, h.idMapper) {
+		return
+	}
+
+	query := r.URL.Query()
+
+	limit := defaultSearchLimit
+	if value := query.Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxSearchLimit {
+			writeProblem(w, r, problemBadRequest, Problem{Mapper: h.name, Detail: fmt.Sprintf("invalid limit '%s', maximum is %d", value, maxSearchLimit)})
+			return
+		}
+	}
+
+	results := h.idMapper.Search(query.Get("q"), limit)
+
+	response := SearchResponse{
+		Results: make([]SearchResult, 0, len(results)),
+	}
+	for _, result := range results {
+		response.Results = append(response.Results, SearchResult{ID: result.ID, Name: result.Name, Score: result.Score})
+	}
+
+	writeResponse(w, r, format, "search", response)
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 )
 
@@ -25,8 +24,5 @@ func NewVersionHandler(version *Version) http.Handler {
 
 // ServeHTTP serves http request
 func (h *versionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	err := json.NewEncoder(w).Encode(h.version)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-	}
+	writeJSON(w, r, http.StatusOK, h.version)
 }
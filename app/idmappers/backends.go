@@ -0,0 +1,95 @@
+package idmappers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielkraic/idmapper/scheduler"
+)
+
+// BackendStatus is state of backend IDMappers read data from
+type BackendStatus struct {
+	Name string
+	// Error is error of check, empty if backend is available
+	Error string
+	// CheckedAt is time of check
+	CheckedAt time.Time
+}
+
+// RunBackendChecks starts periodic checks of backends with given interval (checked only once if zero),
+// each check is limited by timeout
+func (idMappers *IDMappers) RunBackendChecks(interval time.Duration, timeout time.Duration) error {
+	job := func(ctx context.Context) error {
+		result := idMappers.checkBackends(ctx)
+
+		idMappers.mtx.Lock()
+		idMappers.backends = result
+		idMappers.mtx.Unlock()
+		return nil
+	}
+
+	err := idMappers.background.AddWithContext(scheduler.JobWithContextFunc(job), scheduler.Every(interval),
+		scheduler.WithName("backends"), scheduler.WithRunOnStart(), scheduler.WithTimeout(timeout))
+	if err != nil {
+		return fmt.Errorf("failed to setup checks of backends: %s", err)
+	}
+
+	idMappers.background.Start()
+	return nil
+}
+
+// Backends returns state of backends found by last check, it is empty until backends are checked (see RunBackendChecks)
+func (idMappers *IDMappers) Backends() []BackendStatus {
+	idMappers.mtx.Lock()
+	defer idMappers.mtx.Unlock()
+	return idMappers.backends
+}
+
+// checkBackends checks availability of backends of IDMappers: Redis, PostgreSQL and HTTP upstream. Backends which
+// are not configured are not checked
+func (idMappers *IDMappers) checkBackends(ctx context.Context) []BackendStatus {
+	result := []BackendStatus{}
+	check := func(name string, err error) {
+		status := BackendStatus{Name: name, CheckedAt: idMappers.clock.Now()}
+		if err != nil {
+			status.Error = err.Error()
+		}
+		result = append(result, status)
+	}
+
+	if idMappers.client != nil {
+		check("redis", idMappers.client.WithContext(ctx).Ping().Err())
+	}
+
+	if idMappers.db != nil {
+		check("postgresql", idMappers.db.PingContext(ctx))
+	}
+
+	if url := idMappers.config.Loader.URLs.Language; url != "" {
+		check("http", checkHTTP(ctx, url))
+	}
+
+	return result
+}
+
+// checkHTTP checks that HTTP upstream responds to HEAD request without server error
+func checkHTTP(ctx context.Context, url string) error {
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %s", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to HEAD %s: %s", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("HEAD %s returned status %d", url, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package idmappers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/danielkraic/idmapper/iso"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// BuiltinNone disables builtin ISO data, IDMapper reads only from configured source
+	BuiltinNone = "none"
+	// BuiltinFallback uses builtin ISO data when configured source fails before any data were loaded.
+	// Later failures of configured source are reported, so data loaded before are kept
+	BuiltinFallback = "fallback"
+	// BuiltinOnly uses builtin ISO data instead of configured source
+	BuiltinOnly = "only"
+)
+
+// NewBuiltinIDMapper creates IDMapper that reads data from embedded ISO dataset
+func NewBuiltinIDMapper(dataset iso.Dataset) (*idmapper.IDMapper, error) {
+	source, err := iso.NewSource(dataset, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create builtin IDMapper: %s", err)
+	}
+
+	return idmapper.NewIDMapper(source)
+}
+
+// newSourceWithBuiltin combines source created by newSource with builtin ISO dataset according to mode
+func newSourceWithBuiltin(log *logrus.Logger, mode string, dataset iso.Dataset, newSource func() (idmapper.SourceReader, error)) (idmapper.SourceReader, error) {
+	if mode == "" {
+		mode = BuiltinNone
+	}
+
+	switch mode {
+	case BuiltinNone:
+		return newSource()
+	case BuiltinFallback, BuiltinOnly:
+	default:
+		return nil, fmt.Errorf("unknown builtin mode '%s'", mode)
+	}
+
+	builtin, err := iso.NewSource(dataset, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if mode == BuiltinOnly {
+		return builtin, nil
+	}
+
+	primary, err := newSource()
+	if err != nil {
+		return nil, err
+	}
+
+	return &fallbackSource{
+		log:      log,
+		dataset:  dataset,
+		primary:  primary,
+		fallback: builtin,
+	}, nil
+}
+
+type fallbackSource struct {
+	log      *logrus.Logger
+	dataset  iso.Dataset
+	primary  idmapper.SourceReader
+	fallback idmapper.SourceReader
+	// loaded is true once any data were read, builtin data are then not used anymore
+	loaded bool
+	mtx    sync.Mutex
+}
+
+func (source *fallbackSource) Read() (idmapper.ValuesMap, error) {
+	return source.ReadContext(context.Background())
+}
+
+func (source *fallbackSource) ReadContext(ctx context.Context) (idmapper.ValuesMap, error) {
+	result, err := idmapper.ReadContext(ctx, source.primary)
+
+	source.mtx.Lock()
+	defer source.mtx.Unlock()
+
+	if err == nil {
+		source.loaded = true
+		return result, nil
+	}
+	if source.loaded {
+		return nil, err
+	}
+
+	source.log.Warnf("failed to read %s codes from source, using builtin data: %s", source.dataset, err)
+	result, err = source.fallback.Read()
+	if err == nil {
+		source.loaded = true
+	}
+	return result, err
+}
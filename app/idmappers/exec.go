@@ -0,0 +1,222 @@
+package idmappers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/danielkraic/idmapper/idmapper"
+)
+
+const (
+	// ExecFormatJSON is json array of objects with id and name fields
+	ExecFormatJSON = "json"
+	// ExecFormatNDJSON is one json object with id and name fields per line
+	ExecFormatNDJSON = "ndjson"
+	// ExecFormatCSV is csv with id and name columns, optional header line 'id,name' is skipped
+	ExecFormatCSV = "csv"
+
+	defaultExecTimeout       = 30 * time.Second
+	defaultExecMaxOutputSize = 10 << 20
+	// time to wait for output pipes to be closed after command is killed or exits
+	execWaitDelay = time.Second
+	// maximal length of stderr included in error message
+	maxExecStderrSize = 4 << 10
+)
+
+// ExecConfig configuration of command used as IDMapper source
+type ExecConfig struct {
+	// Command is path to executable, exec source is disabled if empty
+	Command string   `mapstructure:"command"`
+	Args    []string `mapstructure:"args"`
+	// Env is list of additional environment variables in form KEY=value
+	Env     []string      `mapstructure:"env"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxOutputSize is maximal size of stdout in bytes
+	MaxOutputSize int64 `mapstructure:"max_output_size"`
+	// Format of stdout: json, ndjson or csv
+	Format string `mapstructure:"format"`
+}
+
+// NewExecIDMapper creates IDMapper that reads data from output of command
+func NewExecIDMapper(config ExecConfig) (*idmapper.IDMapper, error) {
+	source, err := newExecSource(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return idmapper.NewIDMapper(source)
+}
+
+func newExecSource(config ExecConfig) (idmapper.SourceReader, error) {
+	if config.Command == "" {
+		return nil, fmt.Errorf("failed to create Exec IDMapper: empty command")
+	}
+
+	switch config.Format {
+	case "":
+		config.Format = ExecFormatJSON
+	case ExecFormatJSON, ExecFormatNDJSON, ExecFormatCSV:
+	default:
+		return nil, fmt.Errorf("failed to create Exec IDMapper: unknown format '%s'", config.Format)
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = defaultExecTimeout
+	}
+	if config.MaxOutputSize <= 0 {
+		config.MaxOutputSize = defaultExecMaxOutputSize
+	}
+
+	return &execSource{config: config}, nil
+}
+
+type execSource struct {
+	config ExecConfig
+}
+
+type execRecord struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (source *execSource) Read() (idmapper.ValuesMap, error) {
+	return source.ReadContext(context.Background())
+}
+
+func (source *execSource) ReadContext(parent context.Context) (idmapper.ValuesMap, error) {
+	ctx, cancel := context.WithTimeout(parent, source.config.Timeout)
+	defer cancel()
+
+	// command is killed as soon as its output exceeds limit
+	stdout := &limitedBuffer{limit: source.config.MaxOutputSize, onExceeded: cancel}
+	stderr := &limitedBuffer{limit: maxExecStderrSize, truncate: true}
+
+	cmd := exec.CommandContext(ctx, source.config.Command, source.config.Args...)
+	cmd.Env = append(os.Environ(), source.config.Env...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = execWaitDelay
+	startProcessGroup(cmd)
+
+	err := cmd.Run()
+	if err != nil {
+		reason := err.Error()
+		switch {
+		case stdout.exceeded:
+			reason = fmt.Sprintf("output exceeds limit of %d bytes", source.config.MaxOutputSize)
+		case parent.Err() != nil:
+			reason = parent.Err().Error()
+		case ctx.Err() == context.DeadlineExceeded:
+			reason = fmt.Sprintf("timeout %s exceeded", source.config.Timeout)
+		default:
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				reason = fmt.Sprintf("exit code %d", exitErr.ExitCode())
+			}
+		}
+
+		return nil, fmt.Errorf("command '%s' failed: %s, stderr: %q", source.config.Command, reason, strings.TrimSpace(stderr.String()))
+	}
+
+	result, err := decodeExecOutput(source.config.Format, stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s output of command '%s': %s", source.config.Format, source.config.Command, err)
+	}
+
+	return result, nil
+}
+
+func decodeExecOutput(format string, data []byte) (idmapper.ValuesMap, error) {
+	result := make(idmapper.ValuesMap)
+
+	switch format {
+	case ExecFormatNDJSON:
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(nil, len(data)+1)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+
+			var record execRecord
+			err := json.Unmarshal(scanner.Bytes(), &record)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err)
+			}
+			result[record.ID] = record.Name
+		}
+		return result, scanner.Err()
+	case ExecFormatCSV:
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = 2
+		for line := 1; ; line++ {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return result, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if line == 1 && record[0] == "id" && record[1] == "name" {
+				continue
+			}
+			result[record[0]] = record[1]
+		}
+	default:
+		var records []execRecord
+		err := json.Unmarshal(data, &records)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			result[record.ID] = record.Name
+		}
+		return result, nil
+	}
+}
+
+// limitedBuffer is buffer that accepts at most limit bytes.
+// Writes over limit fail unless truncate is set, in which case they are silently discarded
+type limitedBuffer struct {
+	buf        bytes.Buffer
+	limit      int64
+	truncate   bool
+	exceeded   bool
+	onExceeded func()
+}
+
+func (buf *limitedBuffer) Write(p []byte) (int, error) {
+	available := buf.limit - int64(buf.buf.Len())
+	if int64(len(p)) <= available {
+		return buf.buf.Write(p)
+	}
+
+	if !buf.exceeded && buf.onExceeded != nil {
+		buf.onExceeded()
+	}
+	buf.exceeded = true
+	if available > 0 {
+		buf.buf.Write(p[:available])
+	}
+
+	if buf.truncate {
+		return len(p), nil
+	}
+	return int(available), fmt.Errorf("output exceeds limit of %d bytes", buf.limit)
+}
+
+func (buf *limitedBuffer) Bytes() []byte {
+	return buf.buf.Bytes()
+}
+
+func (buf *limitedBuffer) String() string {
+	return buf.buf.String()
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+// +build !unix
+
+package idmappers
+
+import "os/exec"
+
+// startProcessGroup is no-op on platforms without process groups, WaitDelay of command
+// ensures that forked processes holding output pipes do not block it after cancellation
+func startProcessGroup(cmd *exec.Cmd) {}
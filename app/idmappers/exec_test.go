@@ -0,0 +1,88 @@
+package idmappers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danielkraic/idmapper/app/idmappers"
+	"github.com/stretchr/testify/assert"
+)
+
+func shellConfig(script string, format string) idmappers.ExecConfig {
+	return idmappers.ExecConfig{
+		Command: "sh",
+		Args:    []string{"-c", script},
+		Format:  format,
+	}
+}
+
+func TestExecFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		script string
+	}{
+		{"", `echo '[{"id":"sk","name":"Slovakia"},{"id":"cz","name":"Czechia"}]'`},
+		{"ndjson", `printf '{"id":"sk","name":"Slovakia"}\n\n{"id":"cz","name":"Czechia"}\n'`},
+		{"csv", `printf 'id,name\nsk,Slovakia\ncz,Czechia\n'`},
+	}
+
+	for _, test := range tests {
+		idMapper, err := idmappers.NewExecIDMapper(shellConfig(test.script, test.format))
+		assert.Nil(t, err, test.format)
+
+		name, found := idMapper.Get("sk")
+		assert.True(t, found, test.format)
+		assert.Equal(t, "Slovakia", name, test.format)
+
+		name, found = idMapper.Get("cz")
+		assert.True(t, found, test.format)
+		assert.Equal(t, "Czechia", name, test.format)
+	}
+}
+
+func TestExecEnv(t *testing.T) {
+	config := shellConfig(`echo "[{\"id\":\"sk\",\"name\":\"$COUNTRY\"}]"`, "json")
+	config.Env = []string{"COUNTRY=Slovakia"}
+
+	idMapper, err := idmappers.NewExecIDMapper(config)
+	assert.Nil(t, err)
+
+	name, found := idMapper.Get("sk")
+	assert.True(t, found)
+	assert.Equal(t, "Slovakia", name)
+}
+
+func TestExecFailures(t *testing.T) {
+	// sleep is forked by shell and keeps stdout open after shell is killed
+	timeout := shellConfig(`sleep 5; echo '[]'`, "json")
+	timeout.Timeout = 100 * time.Millisecond
+
+	limit := shellConfig(`while true; do echo '[{"id":"sk","name":"Slovakia"}]'; done`, "json")
+	limit.MaxOutputSize = 1024
+
+	tests := []struct {
+		config idmappers.ExecConfig
+		err    string
+	}{
+		{shellConfig(`echo 'no such table' >&2; exit 3`, "json"), `command 'sh' failed: exit code 3, stderr: "no such table"`},
+		{timeout, `command 'sh' failed: timeout 100ms exceeded, stderr: ""`},
+		{limit, `command 'sh' failed: output exceeds limit of 1024 bytes, stderr: ""`},
+		{shellConfig(`echo 'sk;Slovakia'`, "csv"), `failed to decode csv output of command 'sh': record on line 1: wrong number of fields`},
+		{shellConfig(`echo '{"id":"sk"'`, "ndjson"), `failed to decode ndjson output of command 'sh': line 1: unexpected end of JSON input`},
+	}
+
+	for _, test := range tests {
+		start := time.Now()
+		_, err := idmappers.NewExecIDMapper(test.config)
+		assert.EqualError(t, err, test.err)
+		assert.True(t, time.Since(start) < time.Second, test.err)
+	}
+}
+
+func TestExecInvalidConfig(t *testing.T) {
+	_, err := idmappers.NewExecIDMapper(idmappers.ExecConfig{})
+	assert.EqualError(t, err, "failed to create Exec IDMapper: empty command")
+
+	_, err = idmappers.NewExecIDMapper(shellConfig("true", "xml"))
+	assert.EqualError(t, err, "failed to create Exec IDMapper: unknown format 'xml'")
+}
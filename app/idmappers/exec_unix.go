@@ -0,0 +1,18 @@
+//go:build unix
+// +build unix
+
+package idmappers
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// startProcessGroup runs command in its own process group, so that processes forked by command
+// are killed together with it when command is cancelled
+func startProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -1,6 +1,7 @@
 package idmappers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -13,14 +14,24 @@ import (
 
 // NewHTTPIDMapper creates IDMapper that reads data from http
 func NewHTTPIDMapper(log *logrus.Logger, url string, timeout time.Duration) (*idmapper.IDMapper, error) {
+	source, err := newHTTPSource(log, url, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return idmapper.NewIDMapper(source)
+}
+
+func newHTTPSource(log *logrus.Logger, url string, timeout time.Duration) (idmapper.SourceReader, error) {
 	if url == "" {
 		return nil, fmt.Errorf("failed to create HTTP IDMapper: empty url")
 	}
 
-	return idmapper.NewIDMapper(&httpSource{
-		url: url,
-		log: log,
-	})
+	return &httpSource{
+		url:     url,
+		log:     log,
+		timeout: timeout,
+	}, nil
 }
 
 type httpSource struct {
@@ -35,6 +46,10 @@ type httpSourceResponse struct {
 }
 
 func (source httpSource) Read() (idmapper.ValuesMap, error) {
+	return source.ReadContext(context.Background())
+}
+
+func (source httpSource) ReadContext(ctx context.Context) (idmapper.ValuesMap, error) {
 	result := make(idmapper.ValuesMap)
 
 	client := http.Client{
@@ -42,7 +57,12 @@ func (source httpSource) Read() (idmapper.ValuesMap, error) {
 		CheckRedirect: nil,
 	}
 
-	httpResponse, err := client.Get(source.url)
+	req, err := http.NewRequest(http.MethodGet, source.url, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to create request for url %s: %s", source.url, err)
+	}
+
+	httpResponse, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return result, fmt.Errorf("failed to get url %s: %s", source.url, err)
 	}
@@ -1,12 +1,15 @@
 package idmappers
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/danielkraic/idmapper/clock"
 	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/danielkraic/idmapper/iso"
 	"github.com/danielkraic/idmapper/scheduler"
 	"github.com/go-redis/redis"
 	"github.com/sirupsen/logrus"
@@ -15,16 +18,16 @@ import (
 // Config configuration of IDMappers
 type Config struct {
 	Reloader struct {
+		// Workers is maximal number of concurrent reloads, not limited if zero
+		Workers int `mapstructure:"workers"`
+		// State configures persisting of times of last successful reloads
+		State    StateConfig `mapstructure:"state"`
 		Currency struct {
-			Interval      time.Duration `mapstructure:"interval"`
-			RedisHashName string        `mapstructure:"redis_hash_name"`
+			ReloaderConfig `mapstructure:",squash"`
+			RedisHashName  string `mapstructure:"redis_hash_name"`
 		} `mapstructure:"currency"`
-		Country struct {
-			Interval time.Duration `mapstructure:"interval"`
-		} `mapstructure:"country"`
-		Language struct {
-			Interval time.Duration `mapstructure:"interval"`
-		} `mapstructure:"language"`
+		Country  ReloaderConfig `mapstructure:"country"`
+		Language ReloaderConfig `mapstructure:"language"`
 	} `mapstructure:"reloader"`
 	Loader struct {
 		Timeout time.Duration `mapstructure:"timeout"`
@@ -33,7 +36,84 @@ type Config struct {
 			Country  string `mapstructure:"country"`
 			Language string `mapstructure:"language"`
 		} `mapstructure:"urls"`
+		// Builtin sets usage of embedded ISO data for each IDMapper: none, fallback or only
+		Builtin struct {
+			Currency string `mapstructure:"currency"`
+			Country  string `mapstructure:"country"`
+			Language string `mapstructure:"language"`
+		} `mapstructure:"builtin"`
+		// Commands configures IDMappers that read data from output of command instead of their default source
+		Commands struct {
+			Currency ExecConfig `mapstructure:"currency"`
+			Country  ExecConfig `mapstructure:"country"`
+			Language ExecConfig `mapstructure:"language"`
+		} `mapstructure:"commands"`
+		// Scripts configures Lua scripts transforming data read by IDMappers
+		Scripts struct {
+			Currency LuaConfig `mapstructure:"currency"`
+			Country  LuaConfig `mapstructure:"country"`
+			Language LuaConfig `mapstructure:"language"`
+		} `mapstructure:"scripts"`
+		// Transforms configures declarative transform pipelines applied to data read by IDMappers
+		Transforms struct {
+			Currency []TransformConfig `mapstructure:"currency"`
+			Country  []TransformConfig `mapstructure:"country"`
+			Language []TransformConfig `mapstructure:"language"`
+		} `mapstructure:"transforms"`
 	} `mapstructure:"loader"`
+	// Lookup configures ID canonicalization of IDMappers
+	Lookup struct {
+		Currency LookupConfig `mapstructure:"currency"`
+		Country  LookupConfig `mapstructure:"country"`
+		Language LookupConfig `mapstructure:"language"`
+	} `mapstructure:"lookup"`
+	// Leader configures leader election, so only one instance reloads IDMappers from their sources
+	Leader LeaderConfig `mapstructure:"leader"`
+	// Clock is source of time of IDMappers, their reloading and checks of backends, real clock is used if nil
+	Clock clock.Clock `mapstructure:"-"`
+}
+
+// mapperOptions are loader and lookup options of single IDMapper
+type mapperOptions struct {
+	name        string
+	builtinMode string
+	dataset     iso.Dataset
+	command     ExecConfig
+	transforms  []TransformConfig
+	script      LuaConfig
+	lookup      LookupConfig
+	lease       *Lease
+	clock       clock.Clock
+}
+
+func (config *Config) mapperOptions(dataset iso.Dataset, lease *Lease, c clock.Clock) mapperOptions {
+	options := mapperOptions{dataset: dataset, lease: lease, clock: c}
+
+	switch dataset {
+	case iso.Currencies:
+		options.name = "currency"
+		options.builtinMode = config.Loader.Builtin.Currency
+		options.command = config.Loader.Commands.Currency
+		options.transforms = config.Loader.Transforms.Currency
+		options.script = config.Loader.Scripts.Currency
+		options.lookup = config.Lookup.Currency
+	case iso.Countries:
+		options.name = "country"
+		options.builtinMode = config.Loader.Builtin.Country
+		options.command = config.Loader.Commands.Country
+		options.transforms = config.Loader.Transforms.Country
+		options.script = config.Loader.Scripts.Country
+		options.lookup = config.Lookup.Country
+	case iso.Languages:
+		options.name = "language"
+		options.builtinMode = config.Loader.Builtin.Language
+		options.command = config.Loader.Commands.Language
+		options.transforms = config.Loader.Transforms.Language
+		options.script = config.Loader.Scripts.Language
+		options.lookup = config.Lookup.Language
+	}
+
+	return options
 }
 
 // IDMappers consists of available IDMapper objects
@@ -42,24 +122,79 @@ type IDMappers struct {
 	CurrencyCodes *idmapper.IDMapper
 	CountryCodes  *idmapper.IDMapper
 	LanguageCodes *idmapper.IDMapper
-	// this mutex will prevent multiple IDMappers to be reloaded at the same time
-	mtx      sync.Mutex
+	client        *redis.Client
+	db            *sql.DB
+	clock         clock.Clock
+	// loaded is start time of initial load of IDMappers
+	loaded   time.Time
 	reloader *scheduler.Scheduler
+	lease    *Lease
+	// background runs renewal of leader lease and checks of backends. It is separate from reloader,
+	// so they do not wait for free reload worker
+	background *scheduler.Scheduler
+	// reloads are results of last reloads of IDMappers by their names
+	reloads map[string]reloadResult
+	// backends are results of last check of backends
+	backends []BackendStatus
+	mtx      sync.Mutex
+}
+
+// reloadResult is result of reload of IDMapper
+type reloadResult struct {
+	finish time.Time
+	err    error
+}
+
+// MapperStatus is state of IDMapper
+type MapperStatus struct {
+	Name string
+	// Size is number of loaded values
+	Size int
+	// Version is content hash of loaded values
+	Version string
+	// LoadedAt is time of last successful load of values
+	LoadedAt time.Time
+	// LastReload is finish time of last reload, zero if IDMapper was not reloaded since its creation
+	LastReload time.Time
+	// LastError is error of last reload, empty if it was successful
+	LastError string
 }
 
 // NewIDMappers creates IDMappers with available IDMapper objects
 func NewIDMappers(log *logrus.Logger, client *redis.Client, db *sql.DB, config *Config) (*IDMappers, error) {
-	currencyCodes, err := NewRedisIDMapper(client, config.Reloader.Currency.RedisHashName)
+	lease, err := config.Leader.newLease(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup leader election: %s", err)
+	}
+
+	store, err := config.Reloader.State.newStore(log, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup reloader state store: %s", err)
+	}
+
+	c := config.Clock
+	if c == nil {
+		c = clock.Real
+	}
+	loaded := c.Now()
+
+	currencyCodes, err := newIDMapper(log, config.mapperOptions(iso.Currencies, lease, c), func() (idmapper.SourceReader, error) {
+		return newRedisSource(client, config.Reloader.Currency.RedisHashName)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create IDMapper for currency codes: %s", err)
 	}
 
-	countryCodes, err := NewPgSQLIDMapper(log, db, "select id, name from country")
+	countryCodes, err := newIDMapper(log, config.mapperOptions(iso.Countries, lease, c), func() (idmapper.SourceReader, error) {
+		return newPgSQLSource(log, db, "select id, name from country")
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create IDMapper for country codes: %s", err)
 	}
 
-	languageCodes, err := NewHTTPIDMapper(log, config.Loader.URLs.Language, config.Loader.Timeout)
+	languageCodes, err := newIDMapper(log, config.mapperOptions(iso.Languages, lease, c), func() (idmapper.SourceReader, error) {
+		return newHTTPSource(log, config.Loader.URLs.Language, config.Loader.Timeout)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create IDMapper for language codes: %s", err)
 	}
@@ -69,10 +204,54 @@ func NewIDMappers(log *logrus.Logger, client *redis.Client, db *sql.DB, config *
 		CurrencyCodes: currencyCodes,
 		CountryCodes:  countryCodes,
 		LanguageCodes: languageCodes,
-		reloader:      &scheduler.Scheduler{},
+		client:        client,
+		db:            db,
+		clock:         c,
+		loaded:        loaded,
+		reloader:      &scheduler.Scheduler{Workers: config.Reloader.Workers, Clock: c, Store: store},
+		lease:         lease,
+		background:    &scheduler.Scheduler{Clock: c},
+		reloads:       make(map[string]reloadResult),
+		backends:      []BackendStatus{},
 	}, nil
 }
 
+// newIDMapper creates IDMapper reading data from source created by newSource (or from command if configured).
+// Data are then processed by transform pipeline and Lua script, and their IDs are canonicalized
+func newIDMapper(log *logrus.Logger, options mapperOptions, newSource func() (idmapper.SourceReader, error)) (*idmapper.IDMapper, error) {
+	if options.command.Command != "" {
+		newSource = func() (idmapper.SourceReader, error) {
+			return newExecSource(options.command)
+		}
+	}
+
+	source, err := newSourceWithBuiltin(log, options.builtinMode, options.dataset, newSource)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(options.transforms) > 0 {
+		transforms, err := newTransforms(options.transforms)
+		if err != nil {
+			return nil, err
+		}
+		source = idmapper.TransformSource(source, transforms...)
+	}
+
+	if options.script.File != "" {
+		source, err = newLuaSource(source, options.script)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.lease != nil {
+		source = newLeaderSource(log, source, options.lease, options.name)
+	}
+
+	return idmapper.NewIDMapper(source, idmapper.WithClock(options.clock), idmapper.WithNormalizers(options.lookup.normalizers()...), idmapper.WithAliases(options.lookup.Aliases))
+}
+
 // RunReloader starts scheduler for automatic reloading of IDMapper objects
 func (idMappers *IDMappers) RunReloader(log *logrus.Logger) {
 	logOperation := func(description string, err error) {
@@ -83,28 +262,160 @@ func (idMappers *IDMappers) RunReloader(log *logrus.Logger) {
 		}
 	}
 
-	logOperation("setup of CurrencyCodes reloading", idMappers.reloader.AddFunc(func() {
-		idMappers.mtx.Lock()
-		defer idMappers.mtx.Unlock()
-		logOperation("reload of CurrencyCodes", idMappers.CurrencyCodes.Reload())
-	}, idMappers.config.Reloader.Currency.Interval))
+	reload := func(description string, name string) func(ctx context.Context) (bool, error) {
+		return func(ctx context.Context) (bool, error) {
+			changed, err := idMappers.reload(ctx, name)
+			logOperation(description, err)
+			return changed, err
+		}
+	}
 
-	logOperation("setup of CountryCodes reloading", idMappers.reloader.AddFunc(func() {
-		idMappers.mtx.Lock()
-		defer idMappers.mtx.Unlock()
-		logOperation("reload of CountryCodes", idMappers.CountryCodes.Reload())
-	}, idMappers.config.Reloader.Country.Interval))
+	logOperation("setup of CurrencyCodes reloading", idMappers.config.Reloader.Currency.addJob(idMappers.reloader, "currency", idMappers.loaded, reload("reload of CurrencyCodes", "currency")))
+	logOperation("setup of CountryCodes reloading", idMappers.config.Reloader.Country.addJob(idMappers.reloader, "country", idMappers.loaded, reload("reload of CountryCodes", "country")))
+	logOperation("setup of LanguageCodes reloading", idMappers.config.Reloader.Language.addJob(idMappers.reloader, "language", idMappers.loaded, reload("reload of LanguageCodes", "language")))
+
+	if idMappers.lease != nil {
+		logOperation("setup of leader lease renewal", idMappers.background.AddFunc(func() {
+			leader := idMappers.lease.Token() != 0
+			token, err := idMappers.lease.Acquire(context.Background())
+			if err != nil {
+				log.Errorf("renewal of leader lease failed: %s", err)
+			} else if !leader && token != 0 {
+				log.Infof("instance %s became leader with fencing token %d", idMappers.lease.Owner(), token)
+			} else if leader && token == 0 {
+				log.Warnf("instance %s is no longer leader", idMappers.lease.Owner())
+			}
+		}, idMappers.config.Leader.RenewInterval, scheduler.WithName("leader"), scheduler.WithRunOnStart()))
+		idMappers.background.Start()
+	}
+
+	idMappers.reloader.Start()
+}
+
+// Clock returns source of time of IDMappers
+func (idMappers *IDMappers) Clock() clock.Clock {
+	return idMappers.clock
+}
+
+// Names returns names of IDMappers, they are also names of their reload jobs
+func (idMappers *IDMappers) Names() []string {
+	return []string{"currency", "country", "language"}
+}
+
+// Mapper returns IDMapper with given name
+func (idMappers *IDMappers) Mapper(name string) (*idmapper.IDMapper, bool) {
+	switch name {
+	case "currency":
+		return idMappers.CurrencyCodes, true
+	case "country":
+		return idMappers.CountryCodes, true
+	case "language":
+		return idMappers.LanguageCodes, true
+	}
+	return nil, false
+}
+
+// Mappers returns state of IDMappers
+func (idMappers *IDMappers) Mappers() []MapperStatus {
+	result := make([]MapperStatus, 0, len(idMappers.Names()))
+	for _, name := range idMappers.Names() {
+		idMapper, _ := idMappers.Mapper(name)
+		values, version := idMapper.Snapshot()
+
+		status := MapperStatus{
+			Name:     name,
+			Size:     len(values),
+			Version:  version,
+			LoadedAt: idMapper.LoadedAt(),
+		}
 
-	logOperation("setup of LanguageCodes reloading", idMappers.reloader.AddFunc(func() {
 		idMappers.mtx.Lock()
-		defer idMappers.mtx.Unlock()
-		logOperation("reload of LanguageCodes", idMappers.LanguageCodes.Reload())
-	}, idMappers.config.Reloader.Language.Interval))
+		if reload, found := idMappers.reloads[name]; found {
+			status.LastReload = reload.finish
+			if reload.err != nil {
+				status.LastError = reload.err.Error()
+			}
+		}
+		idMappers.mtx.Unlock()
 
-	go idMappers.reloader.Start()
+		result = append(result, status)
+	}
+
+	return result
+}
+
+// Reload runs reload job of IDMapper with given name immediately and waits until reload finishes or ctx is done.
+// With force, job is run also if it is in blackout or outside of its windows.
+// Error is scheduler.RunError if reload failed, other errors mean that reload was not run
+func (idMappers *IDMappers) Reload(ctx context.Context, name string, force bool) error {
+	if force {
+		return idMappers.reloader.ForceRunNowWait(ctx, name)
+	}
+	return idMappers.reloader.RunNowWait(ctx, name)
 }
 
-// StopReloader stops scheduler for automatic reloading of IDMapper objects
+// reload reloads IDMapper with given name and records result of reload. Returns true if reload changed data
+func (idMappers *IDMappers) reload(ctx context.Context, name string) (bool, error) {
+	idMapper, found := idMappers.Mapper(name)
+	if !found {
+		return false, fmt.Errorf("IDMapper %s not found", name)
+	}
+
+	hash := idMapper.Hash()
+	err := idMapper.ReloadContext(ctx)
+
+	idMappers.mtx.Lock()
+	idMappers.reloads[name] = reloadResult{finish: idMappers.clock.Now(), err: err}
+	idMappers.mtx.Unlock()
+
+	return err == nil && idMapper.Hash() != hash, err
+}
+
+// Jobs returns status of reload jobs
+func (idMappers *IDMappers) Jobs() []scheduler.JobStatus {
+	return idMappers.reloader.Jobs()
+}
+
+// RunJob runs reload job immediately
+func (idMappers *IDMappers) RunJob(name string) error {
+	return idMappers.reloader.RunNow(name)
+}
+
+// ForceRunJob runs reload job immediately, also if it is in blackout or outside of its windows
+func (idMappers *IDMappers) ForceRunJob(name string) error {
+	return idMappers.reloader.ForceRunNow(name)
+}
+
+// PauseJob pauses regular runs of reload job
+func (idMappers *IDMappers) PauseJob(name string) error {
+	return idMappers.reloader.Pause(name)
+}
+
+// ResumeJob resumes regular runs of reload job
+func (idMappers *IDMappers) ResumeJob(name string) error {
+	return idMappers.reloader.Resume(name)
+}
+
+// ShutdownReloader stops reloading of IDMappers, renewal of leader lease and checks of backends, and waits until
+// reloads in progress finish or ctx is done. Returns names of reloads still in progress when ctx is done
+func (idMappers *IDMappers) ShutdownReloader(ctx context.Context) ([]string, error) {
+	running, err := idMappers.reloader.Shutdown(ctx)
+	idMappers.stopBackground(ctx)
+	return running, err
+}
+
+// StopReloader stops scheduler for automatic reloading of IDMapper objects, renewal of leader lease and checks of backends
 func (idMappers *IDMappers) StopReloader() {
 	idMappers.reloader.Stop()
+	idMappers.stopBackground(context.Background())
+}
+
+// stopBackground stops renewal of leader lease and checks of backends, and releases lease, so another instance
+// takes over immediately. Lease which failed to be released expires after its ttl
+func (idMappers *IDMappers) stopBackground(ctx context.Context) {
+	// renewal in progress must not acquire lease again after it is released
+	_, _ = idMappers.background.Shutdown(ctx)
+	if idMappers.lease != nil {
+		_ = idMappers.lease.Release(ctx)
+	}
 }
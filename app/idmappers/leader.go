@@ -0,0 +1,133 @@
+package idmappers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/go-redis/redis"
+	"github.com/sirupsen/logrus"
+)
+
+// LeaderConfig configures leader election among instances sharing Redis. Only leader reloads IDMappers
+// from their sources and publishes loaded data to Redis, other instances load data published by leader
+type LeaderConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Key is name of Redis key holding the lease, also used as prefix of keys with published data
+	Key string `mapstructure:"key"`
+	// TTL is duration after which lease of unresponsive leader expires and another instance takes over
+	TTL time.Duration `mapstructure:"ttl"`
+	// RenewInterval is duration between renewals of the lease, must be shorter than TTL
+	RenewInterval time.Duration `mapstructure:"renew_interval"`
+}
+
+// newLease creates Lease according to configuration, returns nil if leader election is disabled
+func (config LeaderConfig) newLease(client *redis.Client) (*Lease, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	if config.RenewInterval <= 0 || config.RenewInterval >= config.TTL {
+		return nil, fmt.Errorf("failed to create lease: renew interval must be positive and shorter than ttl")
+	}
+
+	return NewLease(client, config.Key, "", config.TTL)
+}
+
+// publishScript stores snapshot only if fencing token still owns the lease and no newer snapshot was stored
+var publishScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] .. ':' .. ARGV[2] then
+	return redis.error_reply('lease is not owned by ' .. ARGV[1] .. ' with token ' .. ARGV[2])
+end
+local last = tonumber(redis.call('GET', KEYS[3]) or '0')
+if last > tonumber(ARGV[2]) then
+	return redis.error_reply('snapshot with newer token ' .. last .. ' already published')
+end
+redis.call('SET', KEYS[2], ARGV[3])
+redis.call('SET', KEYS[3], ARGV[2])
+return 1
+`)
+
+// NewLeaderIDMapper creates IDMapper that reads data from source only if lease is acquired and publishes them
+// to Redis under name, otherwise it reads data published by leader
+func NewLeaderIDMapper(log *logrus.Logger, source idmapper.SourceReader, lease *Lease, name string) (*idmapper.IDMapper, error) {
+	return idmapper.NewIDMapper(newLeaderSource(log, source, lease, name))
+}
+
+func newLeaderSource(log *logrus.Logger, source idmapper.SourceReader, lease *Lease, name string) idmapper.SourceReader {
+	return &leaderSource{
+		log:         log,
+		source:      source,
+		lease:       lease,
+		snapshotKey: fmt.Sprintf("%s:snapshot:%s", lease.key, name),
+	}
+}
+
+type leaderSource struct {
+	log         *logrus.Logger
+	source      idmapper.SourceReader
+	lease       *Lease
+	snapshotKey string
+}
+
+func (s *leaderSource) Read() (idmapper.ValuesMap, error) {
+	return s.ReadContext(context.Background())
+}
+
+func (s *leaderSource) ReadContext(ctx context.Context) (idmapper.ValuesMap, error) {
+	token, err := s.lease.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == 0 {
+		values, found, err := s.readSnapshot(ctx)
+		if err != nil || found {
+			return values, err
+		}
+
+		// leader has not published data yet
+		return idmapper.ReadContext(ctx, s.source)
+	}
+
+	values, err := idmapper.ReadContext(ctx, s.source)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.publishSnapshot(ctx, token, values)
+	if err != nil {
+		s.log.Warnf("failed to publish snapshot %s: %s", s.snapshotKey, err)
+	}
+
+	return values, nil
+}
+
+func (s *leaderSource) readSnapshot(ctx context.Context) (idmapper.ValuesMap, bool, error) {
+	data, err := s.lease.client.WithContext(ctx).Get(s.snapshotKey).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to GET snapshot %s: %s", s.snapshotKey, err)
+	}
+
+	var values idmapper.ValuesMap
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode snapshot %s: %s", s.snapshotKey, err)
+	}
+
+	return values, true, nil
+}
+
+func (s *leaderSource) publishSnapshot(ctx context.Context, token int64, values idmapper.ValuesMap) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %s", err)
+	}
+
+	return publishScript.Run(s.lease.client.WithContext(ctx), []string{s.lease.key, s.snapshotKey, s.snapshotKey + ":token"}, s.lease.owner, token, data).Err()
+}
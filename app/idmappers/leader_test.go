@@ -0,0 +1,162 @@
+package idmappers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/danielkraic/idmapper/app/idmappers"
+	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/go-redis/redis"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to create miniredis: %s", err)
+	}
+
+	return mr, redis.NewClient(&redis.Options{Addr: mr.Addr()})
+}
+
+func newLease(t *testing.T, client *redis.Client, owner string) *idmappers.Lease {
+	lease, err := idmappers.NewLease(client, "leader", owner, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create lease: %s", err)
+	}
+
+	return lease
+}
+
+func TestLease(t *testing.T) {
+	mr, client := newRedis(t)
+	defer mr.Close()
+	ctx := context.Background()
+
+	first := newLease(t, client, "first")
+	second := newLease(t, client, "second")
+
+	token, err := first.Acquire(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), token)
+	assert.Equal(t, int64(1), first.Token())
+
+	token, err = second.Acquire(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), token)
+
+	// renewal keeps fencing token
+	mr.FastForward(40 * time.Second)
+	token, err = first.Acquire(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), token)
+
+	// lease of leader which stopped renewing expires
+	mr.FastForward(70 * time.Second)
+	token, err = second.Acquire(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), token)
+
+	token, err = first.Acquire(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), token)
+	assert.Equal(t, int64(0), first.Token())
+
+	// only owner releases the lease
+	assert.Nil(t, first.Release(ctx))
+	token, err = first.Acquire(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), token)
+
+	assert.Nil(t, second.Release(ctx))
+	token, err = first.Acquire(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), token)
+
+	_, err = idmappers.NewLease(nil, "leader", "", time.Minute)
+	assert.NotNil(t, err)
+	_, err = idmappers.NewLease(client, "leader", "", 0)
+	assert.NotNil(t, err)
+}
+
+func countingSource(reads *int, values idmapper.ValuesMap) idmapper.SourceReader {
+	return idmapper.SourceReaderFunc(func() (idmapper.ValuesMap, error) {
+		*reads++
+		return values, nil
+	})
+}
+
+func TestLeaderIDMapper(t *testing.T) {
+	mr, client := newRedis(t)
+	defer mr.Close()
+	log := logrus.New()
+
+	firstLease := newLease(t, client, "first")
+	secondLease := newLease(t, client, "second")
+
+	var firstReads, secondReads int
+	first, err := idmappers.NewLeaderIDMapper(log, countingSource(&firstReads, idmapper.ValuesMap{"sk": "Slovakia"}), firstLease, "country")
+	assert.Nil(t, err)
+	second, err := idmappers.NewLeaderIDMapper(log, countingSource(&secondReads, idmapper.ValuesMap{"cz": "Czechia"}), secondLease, "country")
+	assert.Nil(t, err)
+
+	// follower loads data published by leader without reading its source
+	assert.Equal(t, 1, firstReads)
+	assert.Equal(t, 0, secondReads)
+	name, found := second.Get("sk")
+	assert.True(t, found)
+	assert.Equal(t, "Slovakia", name)
+
+	// follower takes over after lease of leader expires
+	mr.FastForward(2 * time.Minute)
+	assert.Nil(t, second.Reload())
+	assert.Nil(t, first.Reload())
+	assert.Equal(t, 1, firstReads)
+	assert.Equal(t, 1, secondReads)
+
+	name, found = first.Get("cz")
+	assert.True(t, found)
+	assert.Equal(t, "Czechia", name)
+	_, found = first.Get("sk")
+	assert.False(t, found)
+}
+
+func TestLeaderIDMapperFencing(t *testing.T) {
+	mr, client := newRedis(t)
+	defer mr.Close()
+	log := logrus.New()
+
+	firstLease := newLease(t, client, "first")
+	secondLease := newLease(t, client, "second")
+
+	var secondReads int
+	second := countingSource(&secondReads, idmapper.ValuesMap{"cz": "Czechia"})
+
+	paused := false
+	first, err := idmappers.NewLeaderIDMapper(log, idmapper.SourceReaderFunc(func() (idmapper.ValuesMap, error) {
+		if paused {
+			// leader stalls during reload until its lease expires and another instance takes over
+			mr.FastForward(2 * time.Minute)
+			_, err := idmappers.NewLeaderIDMapper(log, second, secondLease, "country")
+			assert.Nil(t, err)
+		}
+		return idmapper.ValuesMap{"sk": "Slovakia"}, nil
+	}), firstLease, "country")
+	assert.Nil(t, err)
+
+	paused = true
+	assert.Nil(t, first.Reload())
+	assert.Equal(t, 1, secondReads)
+
+	// data of stale leader are not published
+	follower, err := idmappers.NewLeaderIDMapper(log, countingSource(new(int), nil), newLease(t, client, "third"), "country")
+	assert.Nil(t, err)
+	_, found := follower.Get("sk")
+	assert.False(t, found)
+	name, found := follower.Get("cz")
+	assert.True(t, found)
+	assert.Equal(t, "Czechia", name)
+}
@@ -0,0 +1,118 @@
+package idmappers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// acquireScript acquires lease or renews lease already owned by caller. Every new acquisition increments
+// fencing token, returns fencing token of owned lease or zero if lease is owned by another instance
+var acquireScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current then
+	local owner, token = string.match(current, '^(.*):(%d+)$')
+	if owner == ARGV[1] then
+		redis.call('PEXPIRE', KEYS[1], ARGV[2])
+		return tonumber(token)
+	end
+	return 0
+end
+local token = redis.call('INCR', KEYS[2])
+redis.call('SET', KEYS[1], ARGV[1] .. ':' .. token, 'PX', ARGV[2])
+return token
+`)
+
+// releaseScript deletes lease if it is owned by caller
+var releaseScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current and string.match(current, '^(.*):%d+$') == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// Lease is Redis based lease electing single leader among instances sharing the same key
+type Lease struct {
+	client *redis.Client
+	key    string
+	owner  string
+	ttl    time.Duration
+	token  int64
+}
+
+// NewLease creates Lease identified by key, owner identifies instance holding the lease
+func NewLease(client *redis.Client, key string, owner string, ttl time.Duration) (*Lease, error) {
+	if client == nil {
+		return nil, fmt.Errorf("failed to create lease: redis client is nil")
+	}
+
+	if ttl <= 0 {
+		return nil, fmt.Errorf("failed to create lease: ttl must be positive")
+	}
+
+	if owner == "" {
+		var err error
+		owner, err = newLeaseOwner()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create lease owner: %s", err)
+		}
+	}
+
+	return &Lease{client: client, key: key, owner: owner, ttl: ttl}, nil
+}
+
+func newLeaseOwner() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+
+	random := make([]byte, 4)
+	_, err = rand.Read(random)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(random)), nil
+}
+
+// Owner returns identifier of instance holding the lease
+func (l *Lease) Owner() string {
+	return l.owner
+}
+
+// Acquire acquires or renews the lease. Returns fencing token if instance is leader, zero otherwise
+func (l *Lease) Acquire(ctx context.Context) (int64, error) {
+	token, err := acquireScript.Run(l.client.WithContext(ctx), []string{l.key, l.key + ":token"}, l.owner, int64(l.ttl/time.Millisecond)).Int64()
+	if err != nil {
+		atomic.StoreInt64(&l.token, 0)
+		return 0, fmt.Errorf("failed to acquire lease %s: %s", l.key, err)
+	}
+
+	atomic.StoreInt64(&l.token, token)
+	return token, nil
+}
+
+// Token returns fencing token of last successful acquisition, zero if instance is not leader
+func (l *Lease) Token() int64 {
+	return atomic.LoadInt64(&l.token)
+}
+
+// Release releases the lease if instance is leader, so another instance can take over without waiting for ttl
+func (l *Lease) Release(ctx context.Context) error {
+	atomic.StoreInt64(&l.token, 0)
+
+	err := releaseScript.Run(l.client.WithContext(ctx), []string{l.key}, l.owner).Err()
+	if err != nil {
+		return fmt.Errorf("failed to release lease %s: %s", l.key, err)
+	}
+
+	return nil
+}
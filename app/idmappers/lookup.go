@@ -0,0 +1,32 @@
+package idmappers
+
+import "github.com/danielkraic/idmapper/idmapper"
+
+// LookupConfig configuration of ID canonicalization applied to IDs read from source and to requested IDs
+type LookupConfig struct {
+	// Trim removes leading and trailing white space
+	Trim bool `mapstructure:"trim"`
+	// NormalizeUnicode converts ID to Unicode Normalization Form C
+	NormalizeUnicode bool `mapstructure:"normalize_unicode"`
+	// FoldCase converts ID to lower case
+	FoldCase bool `mapstructure:"fold_case"`
+	// Aliases maps alternative IDs to canonical IDs, applied after other normalizers which also convert aliases and canonical IDs.
+	// Values read from source with alias IDs are dropped if source contains also canonical IDs
+	Aliases map[string]string `mapstructure:"aliases"`
+}
+
+func (config LookupConfig) normalizers() []idmapper.Normalizer {
+	var normalizers []idmapper.Normalizer
+
+	if config.Trim {
+		normalizers = append(normalizers, idmapper.TrimID())
+	}
+	if config.NormalizeUnicode {
+		normalizers = append(normalizers, idmapper.NormalizeID())
+	}
+	if config.FoldCase {
+		normalizers = append(normalizers, idmapper.FoldCase())
+	}
+
+	return normalizers
+}
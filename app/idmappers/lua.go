@@ -0,0 +1,264 @@
+package idmappers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/danielkraic/idmapper/idmapper"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+const (
+	// luaTransformFunc is name of Lua function called for each record
+	luaTransformFunc = "transform"
+	// luaTransformAllFunc is name of Lua function called with all records
+	luaTransformAllFunc = "transform_all"
+
+	defaultLuaTimeout = time.Second
+)
+
+// LuaConfig configuration of Lua script transforming data read from IDMapper source.
+//
+// Script must define one of global functions:
+//
+//	transform(id, name)  called for each record, returns new id and name, nil to drop record
+//	transform_all(values) called with table of all records (id -> name), returns new table
+//
+// Script may call error() to reject data, reload of IDMapper fails in that case.
+// Only base, table, string and math libraries are available to script.
+type LuaConfig struct {
+	// File is path to Lua script, transformation is disabled if empty
+	File string `mapstructure:"file"`
+	// Timeout is maximal time of script execution during one reload
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// unsafe functions removed from base library
+var luaUnsafeFuncs = []string{"dofile", "loadfile", "load", "loadstring", "require", "module", "collectgarbage", "getfenv", "setfenv", "newproxy", "_printregs"}
+
+// NewLuaIDMapper creates IDMapper that reads data from source and transforms them by Lua script
+func NewLuaIDMapper(source idmapper.SourceReader, config LuaConfig) (*idmapper.IDMapper, error) {
+	luaSource, err := newLuaSource(source, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return idmapper.NewIDMapper(luaSource)
+}
+
+func newLuaSource(source idmapper.SourceReader, config LuaConfig) (idmapper.SourceReader, error) {
+	script, err := ioutil.ReadFile(config.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lua script: %s", err)
+	}
+
+	chunk, err := parse.Parse(bytes.NewReader(script), config.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lua script %s: %s", config.File, err)
+	}
+
+	proto, err := lua.Compile(chunk, config.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile lua script %s: %s", config.File, err)
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = defaultLuaTimeout
+	}
+
+	luaSource := &luaSource{
+		source: source,
+		config: config,
+		proto:  proto,
+	}
+
+	// run script once to check it defines transformation function
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+
+	L, err := luaSource.newState(ctx)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("lua script %s: timeout %s exceeded", config.File, config.Timeout)
+		}
+		return nil, err
+	}
+	L.Close()
+
+	return luaSource, nil
+}
+
+type luaSource struct {
+	source idmapper.SourceReader
+	config LuaConfig
+	proto  *lua.FunctionProto
+}
+
+func (source *luaSource) Read() (idmapper.ValuesMap, error) {
+	return source.ReadContext(context.Background())
+}
+
+func (source *luaSource) ReadContext(parent context.Context) (idmapper.ValuesMap, error) {
+	values, err := idmapper.ReadContext(parent, source.source)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(parent, source.config.Timeout)
+	defer cancel()
+
+	L, err := source.newState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer L.Close()
+
+	var result idmapper.ValuesMap
+	if fn, ok := L.GetGlobal(luaTransformAllFunc).(*lua.LFunction); ok {
+		result, err = source.transformAll(L, fn, values)
+	} else {
+		result, err = source.transform(L, L.GetGlobal(luaTransformFunc).(*lua.LFunction), values)
+	}
+
+	if err != nil {
+		if parent.Err() != nil {
+			return nil, fmt.Errorf("lua script %s: %s", source.config.File, parent.Err())
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("lua script %s: timeout %s exceeded", source.config.File, source.config.Timeout)
+		}
+		return nil, fmt.Errorf("lua script %s: %s", source.config.File, err)
+	}
+
+	return result, nil
+}
+
+// newState creates sandboxed Lua state and runs script in it
+func (source *luaSource) newState(ctx context.Context) (*lua.LState, error) {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+
+	for _, lib := range []struct {
+		name string
+		fn   lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.fn))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+
+	for _, name := range luaUnsafeFuncs {
+		L.SetGlobal(name, lua.LNil)
+	}
+
+	L.SetContext(ctx)
+
+	L.Push(L.NewFunctionFromProto(source.proto))
+	err := L.PCall(0, lua.MultRet, nil)
+	if err != nil {
+		L.Close()
+		return nil, fmt.Errorf("failed to run lua script %s: %s", source.config.File, luaErrorMessage(err))
+	}
+
+	_, hasTransform := L.GetGlobal(luaTransformFunc).(*lua.LFunction)
+	_, hasTransformAll := L.GetGlobal(luaTransformAllFunc).(*lua.LFunction)
+	if !hasTransform && !hasTransformAll {
+		L.Close()
+		return nil, fmt.Errorf("lua script %s: function %s or %s is not defined", source.config.File, luaTransformFunc, luaTransformAllFunc)
+	}
+
+	return L, nil
+}
+
+func (source *luaSource) transform(L *lua.LState, fn *lua.LFunction, values idmapper.ValuesMap) (idmapper.ValuesMap, error) {
+	result := make(idmapper.ValuesMap, len(values))
+
+	// values are transformed in order of their IDs, so the last one of colliding new IDs is always the same
+	for _, id := range sortedIDs(values) {
+		name := values[id]
+		err := L.CallByParam(lua.P{Fn: fn, NRet: 2, Protect: true}, lua.LString(id), lua.LString(name))
+		if err != nil {
+			return nil, fmt.Errorf("%s(%q) failed: %s", luaTransformFunc, id, luaErrorMessage(err))
+		}
+
+		newID, newName := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+
+		if newID == lua.LNil {
+			continue
+		}
+		if newName == lua.LNil {
+			newName = lua.LString(name)
+		}
+
+		if !lua.LVCanConvToString(newID) || !lua.LVCanConvToString(newName) {
+			return nil, fmt.Errorf("%s(%q) returned %s and %s, expected strings", luaTransformFunc, id, newID.Type(), newName.Type())
+		}
+
+		result[lua.LVAsString(newID)] = lua.LVAsString(newName)
+	}
+
+	return result, nil
+}
+
+func (source *luaSource) transformAll(L *lua.LState, fn *lua.LFunction, values idmapper.ValuesMap) (idmapper.ValuesMap, error) {
+	// table is iterated by pairs in order of insertion, so it is filled in order of IDs
+	table := L.CreateTable(0, len(values))
+	for _, id := range sortedIDs(values) {
+		table.RawSetString(id, lua.LString(values[id]))
+	}
+
+	err := L.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, table)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %s", luaTransformAllFunc, luaErrorMessage(err))
+	}
+
+	returned := L.Get(-1)
+	L.Pop(1)
+
+	newTable, ok := returned.(*lua.LTable)
+	if !ok {
+		return nil, fmt.Errorf("%s returned %s, expected table", luaTransformAllFunc, returned.Type())
+	}
+
+	result := make(idmapper.ValuesMap)
+	newTable.ForEach(func(key lua.LValue, value lua.LValue) {
+		if err != nil {
+			return
+		}
+		if !lua.LVCanConvToString(key) || !lua.LVCanConvToString(value) {
+			err = fmt.Errorf("%s returned table with %s key and %s value, expected strings", luaTransformAllFunc, key.Type(), value.Type())
+			return
+		}
+		result[lua.LVAsString(key)] = lua.LVAsString(value)
+	})
+
+	return result, err
+}
+
+// luaErrorMessage returns error message without Lua stack trace
+func luaErrorMessage(err error) string {
+	if apiErr, ok := err.(*lua.ApiError); ok {
+		return apiErr.Object.String()
+	}
+	return err.Error()
+}
+
+// sortedIDs returns IDs of values in ascending order
+func sortedIDs(values idmapper.ValuesMap) []string {
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,154 @@
+package idmappers_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielkraic/idmapper/app/idmappers"
+	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/stretchr/testify/assert"
+)
+
+var luaTestValues = idmapper.ValuesMap{
+	"SK": "Slovakia",
+	"CZ": "Czechia",
+	"YU": "Yugoslavia",
+}
+
+// luaTestConfig writes script to temporary file and returns configuration of Lua script with cleanup function removing it
+func luaTestConfig(t *testing.T, script string, timeout time.Duration) (idmappers.LuaConfig, func()) {
+	file, err := ioutil.TempFile("", "idmapper-*.lua")
+	assert.Nil(t, err)
+	_, err = file.WriteString(script)
+	assert.Nil(t, err)
+	assert.Nil(t, file.Close())
+
+	cleanup := func() {
+		assert.Nil(t, os.Remove(file.Name()))
+	}
+
+	return idmappers.LuaConfig{File: file.Name(), Timeout: timeout}, cleanup
+}
+
+func luaTestSource() idmapper.SourceReader {
+	return idmapper.SourceReaderFunc(func() (idmapper.ValuesMap, error) {
+		return luaTestValues, nil
+	})
+}
+
+func TestLuaTransform(t *testing.T) {
+	config, cleanup := luaTestConfig(t, `
+function transform(id, name)
+	if id == "YU" then
+		return nil
+	end
+	return string.lower(id), name
+end
+`, 0)
+	defer cleanup()
+
+	idMapper, err := idmappers.NewLuaIDMapper(luaTestSource(), config)
+	assert.Nil(t, err)
+
+	values, _ := idMapper.Snapshot()
+	assert.Equal(t, idmapper.ValuesMap{"sk": "Slovakia", "cz": "Czechia"}, values)
+}
+
+func TestLuaTransformAll(t *testing.T) {
+	config, cleanup := luaTestConfig(t, `
+function transform_all(values)
+	local result = {}
+	for id, name in pairs(values) do
+		result[id] = string.upper(name)
+	end
+	result["XK"] = "KOSOVO"
+	return result
+end
+`, 0)
+	defer cleanup()
+
+	idMapper, err := idmappers.NewLuaIDMapper(luaTestSource(), config)
+	assert.Nil(t, err)
+
+	values, _ := idMapper.Snapshot()
+	assert.Equal(t, idmapper.ValuesMap{"SK": "SLOVAKIA", "CZ": "CZECHIA", "YU": "YUGOSLAVIA", "XK": "KOSOVO"}, values)
+}
+
+func TestLuaCollision(t *testing.T) {
+	scripts := []string{
+		`function transform(id, name) return "XX", name end`,
+		`function transform_all(values)
+	local result = {}
+	for id, name in pairs(values) do
+		result["XX"] = name
+	end
+	return result
+end`,
+	}
+
+	// values are processed in order of IDs (CZ, SK, YU), so the last one always wins
+	for _, script := range scripts {
+		config, cleanup := luaTestConfig(t, script, 0)
+
+		idMapper, err := idmappers.NewLuaIDMapper(luaTestSource(), config)
+		assert.Nil(t, err)
+
+		for i := 0; i < 20; i++ {
+			assert.Nil(t, idMapper.Reload())
+			values, _ := idMapper.Snapshot()
+			assert.Equal(t, idmapper.ValuesMap{"XX": "Yugoslavia"}, values)
+		}
+		cleanup()
+	}
+}
+
+func TestLuaErrors(t *testing.T) {
+	tests := []struct {
+		script string
+		err    string
+	}{
+		{`function transform(id, name) if id == "YU" then error("retired code " .. id) end return id, name end`, `transform("YU") failed: <script>:1: retired code YU`},
+		{`function transform(id, name) return id, {} end`, `") returned string and table, expected strings`},
+		{`function transform_all(values) return 1 end`, `transform_all returned number, expected table`},
+		{`function transform(id, name) while true do end end`, `timeout 100ms exceeded`},
+		{`while true do end function transform(id, name) return id, name end`, `timeout 100ms exceeded`},
+	}
+
+	for _, test := range tests {
+		config, cleanup := luaTestConfig(t, test.script, 100*time.Millisecond)
+
+		start := time.Now()
+		_, err := idmappers.NewLuaIDMapper(luaTestSource(), config)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "lua script "+config.File+": ")
+			assert.Contains(t, err.Error(), strings.Replace(test.err, "<script>", config.File, 1))
+		}
+		assert.True(t, time.Since(start) < time.Second, test.script)
+		cleanup()
+	}
+}
+
+func TestLuaSandbox(t *testing.T) {
+	tests := []struct {
+		script string
+		err    string
+	}{
+		{`os.execute("true") function transform(id, name) return id, name end`, "attempt to index a non-table object(nil)"},
+		{`io.open("/etc/passwd") function transform(id, name) return id, name end`, "attempt to index a non-table object(nil)"},
+		{`dofile("/etc/passwd") function transform(id, name) return id, name end`, "attempt to call a non-function object"},
+		{`local x = 1`, "function transform or transform_all is not defined"},
+		{`function transform(id, name`, "failed to parse lua script"},
+	}
+
+	for _, test := range tests {
+		config, cleanup := luaTestConfig(t, test.script, 0)
+		_, err := idmappers.NewLuaIDMapper(luaTestSource(), config)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), test.err)
+		}
+		cleanup()
+	}
+}
@@ -0,0 +1,13 @@
+package idmappers
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// reloadInterval is current duration between regular reloads of IDMappers
+var reloadInterval = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "idmapper_reload_interval_seconds",
+	Help: "Current duration between regular reloads of IDMapper.",
+}, []string{"mapper"})
+
+func init() {
+	prometheus.MustRegister(reloadInterval)
+}
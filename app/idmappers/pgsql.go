@@ -1,6 +1,7 @@
 package idmappers
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -10,14 +11,24 @@ import (
 
 // NewPgSQLIDMapper creates IDMapper that reads data from sql database
 func NewPgSQLIDMapper(log *logrus.Logger, db *sql.DB, query string) (*idmapper.IDMapper, error) {
+	source, err := newPgSQLSource(log, db, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return idmapper.NewIDMapper(source)
+}
+
+func newPgSQLSource(log *logrus.Logger, db *sql.DB, query string) (idmapper.SourceReader, error) {
 	if db == nil {
 		return nil, fmt.Errorf("failed to create PgSQL IDMapper: sql.DB is nil")
 	}
-	return idmapper.NewIDMapper(&pgSQLSource{
+
+	return &pgSQLSource{
 		log:   log,
 		query: query,
 		db:    db,
-	})
+	}, nil
 }
 
 type pgSQLSource struct {
@@ -27,7 +38,11 @@ type pgSQLSource struct {
 }
 
 func (source *pgSQLSource) Read() (idmapper.ValuesMap, error) {
-	rows, err := source.db.Query(source.query)
+	return source.ReadContext(context.Background())
+}
+
+func (source *pgSQLSource) ReadContext(ctx context.Context) (idmapper.ValuesMap, error) {
+	rows, err := source.db.QueryContext(ctx, source.query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query '%s': %s", source.query, err)
 	}
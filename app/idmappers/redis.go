@@ -1,6 +1,7 @@
 package idmappers
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/danielkraic/idmapper/idmapper"
@@ -9,11 +10,20 @@ import (
 
 // NewRedisIDMapper creates IDMapper that reads data from redis
 func NewRedisIDMapper(client *redis.Client, hashName string) (*idmapper.IDMapper, error) {
+	source, err := newRedisSource(client, hashName)
+	if err != nil {
+		return nil, err
+	}
+
+	return idmapper.NewIDMapper(source)
+}
+
+func newRedisSource(client *redis.Client, hashName string) (idmapper.SourceReader, error) {
 	if client == nil {
 		return nil, fmt.Errorf("failed to create Redis IDMapper: redis client is nil")
 	}
 
-	return idmapper.NewIDMapper(&redisSource{client: client, hashName: hashName})
+	return &redisSource{client: client, hashName: hashName}, nil
 }
 
 type redisSource struct {
@@ -22,7 +32,11 @@ type redisSource struct {
 }
 
 func (r *redisSource) Read() (idmapper.ValuesMap, error) {
-	result, err := r.client.HGetAll(r.hashName).Result()
+	return r.ReadContext(context.Background())
+}
+
+func (r *redisSource) ReadContext(ctx context.Context) (idmapper.ValuesMap, error) {
+	result, err := r.client.WithContext(ctx).HGetAll(r.hashName).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to HGET hash %s: %s", r.hashName, err)
 	}
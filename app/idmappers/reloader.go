@@ -0,0 +1,192 @@
+package idmappers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/danielkraic/idmapper/scheduler"
+)
+
+// ReloaderConfig configuration of automatic reloading of single IDMapper
+type ReloaderConfig struct {
+	// Interval is duration between reloads, used if Cron is empty
+	Interval time.Duration `mapstructure:"interval"`
+	// Cron is cron expression defining times of reloads (see scheduler.ParseCron), e.g. 'CRON_TZ=UTC 5 6 * * MON-FRI'
+	Cron string `mapstructure:"cron"`
+	// Jitter is maximal random delay of each reload, so multiple instances do not reload at the same time
+	Jitter time.Duration `mapstructure:"jitter"`
+	// InitialDelay postpones first reload after start
+	InitialDelay time.Duration `mapstructure:"initial_delay"`
+	// RunOnStart reloads IDMapper immediately after start (or after initial delay)
+	RunOnStart bool `mapstructure:"run_on_start"`
+	// CatchUp is policy applied to reloads missed while application was not running: once, skip or all
+	CatchUp string `mapstructure:"catch_up"`
+	// Overlap is policy applied when reload should start while previous one has not finished yet: skip, queue or allow
+	Overlap string `mapstructure:"overlap"`
+	// Timeout is maximal duration of single reload, not limited if zero
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Retry configures retrying of failed reloads before next regular reload
+	Retry struct {
+		// InitialBackoff is delay before first retry, failed reloads are not retried if zero
+		InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+		// MaxBackoff is maximal delay between retries
+		MaxBackoff time.Duration `mapstructure:"max_backoff"`
+	} `mapstructure:"retry"`
+	// Adaptive configures adaptive reload interval, used instead of Interval and Cron if enabled
+	Adaptive struct {
+		Enabled bool `mapstructure:"enabled"`
+		// MinInterval is lower bound of interval, interval is halved after each reload that changed data
+		MinInterval time.Duration `mapstructure:"min_interval"`
+		// MaxInterval is upper bound of interval, interval is doubled after each reload that did not change data
+		MaxInterval time.Duration `mapstructure:"max_interval"`
+	} `mapstructure:"adaptive"`
+	// Blackouts are windows during which reloads are postponed until blackout ends
+	Blackouts []WindowConfig `mapstructure:"blackouts"`
+	// Windows are windows during which reloads are allowed, reloads outside of them are postponed until next window opens
+	Windows []WindowConfig `mapstructure:"windows"`
+}
+
+// WindowConfig configures recurring window defined by Cron and Duration or one-off window defined by Start and End
+type WindowConfig struct {
+	// Cron is cron expression of window starts, e.g. 'CRON_TZ=Europe/Bratislava 0 0 28-31 * *'
+	Cron string `mapstructure:"cron"`
+	// Duration is duration of recurring window
+	Duration time.Duration `mapstructure:"duration"`
+	// Start is beginning of one-off window in format '2006-01-02 15:04:05', '2006-01-02' or RFC3339
+	Start string `mapstructure:"start"`
+	// End is end of one-off window in the same format as Start
+	End string `mapstructure:"end"`
+	// TimeZone is time zone of Start and End without offset, local time zone is used if empty
+	TimeZone string `mapstructure:"time_zone"`
+}
+
+var windowTimeFormats = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+func (config WindowConfig) window() (scheduler.Window, error) {
+	if config.Cron != "" {
+		schedule, err := scheduler.ParseCron(config.Cron)
+		if err != nil {
+			return nil, err
+		}
+		return scheduler.CronWindow(schedule, config.Duration)
+	}
+
+	location := time.Local
+	if config.TimeZone != "" {
+		var err error
+		location, err = time.LoadLocation(config.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone '%s': %s", config.TimeZone, err)
+		}
+	}
+
+	start, err := parseWindowTime(config.Start, location)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseWindowTime(config.End, location)
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("window end %s is not after its start %s", config.End, config.Start)
+	}
+
+	return scheduler.Period{Start: start, End: end}, nil
+}
+
+func parseWindowTime(value string, location *time.Location) (time.Time, error) {
+	for _, format := range windowTimeFormats {
+		t, err := time.ParseInLocation(format, value, location)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid window time '%s'", value)
+}
+
+func windows(configs []WindowConfig) ([]scheduler.Window, error) {
+	result := make([]scheduler.Window, 0, len(configs))
+	for _, config := range configs {
+		window, err := config.window()
+		if err != nil {
+			return nil, fmt.Errorf("invalid window: %s", err)
+		}
+		result = append(result, window)
+	}
+	return result, nil
+}
+
+// addJob adds named reload job to reloader according to configuration. Reload returns true if it changed data.
+// Loaded is start time of initial load of data, it is recorded as last successful run of job
+func (config ReloaderConfig) addJob(reloader *scheduler.Scheduler, name string, loaded time.Time, reload func(ctx context.Context) (bool, error)) error {
+	schedule := scheduler.Every(config.Interval)
+	interval := config.Interval
+
+	var adaptive *scheduler.AdaptiveSchedule
+	switch {
+	case config.Adaptive.Enabled:
+		var err error
+		adaptive, err = scheduler.Adaptive(config.Adaptive.MinInterval, config.Adaptive.MaxInterval, config.Interval)
+		if err != nil {
+			return err
+		}
+		schedule = adaptive
+		interval = adaptive.Interval()
+	case config.Cron != "":
+		var err error
+		schedule, err = scheduler.ParseCron(config.Cron)
+		if err != nil {
+			return err
+		}
+	}
+
+	// interval of cron schedule is not exposed
+	if config.Adaptive.Enabled || config.Cron == "" {
+		reloadInterval.WithLabelValues(name).Set(interval.Seconds())
+	}
+
+	options := []scheduler.Option{
+		scheduler.WithName(name),
+		scheduler.WithJitter(config.Jitter),
+		scheduler.WithInitialDelay(config.InitialDelay),
+		scheduler.WithOverlap(scheduler.OverlapPolicy(config.Overlap)),
+		scheduler.WithCatchUp(scheduler.CatchUpPolicy(config.CatchUp)),
+		scheduler.WithLastSuccess(loaded),
+		scheduler.WithTimeout(config.Timeout),
+		scheduler.WithRetry(scheduler.RetryPolicy{
+			InitialBackoff: config.Retry.InitialBackoff,
+			MaxBackoff:     config.Retry.MaxBackoff,
+		}),
+	}
+	if config.RunOnStart {
+		options = append(options, scheduler.WithRunOnStart())
+	}
+
+	blackouts, err := windows(config.Blackouts)
+	if err != nil {
+		return err
+	}
+	allowed, err := windows(config.Windows)
+	if err != nil {
+		return err
+	}
+	options = append(options, scheduler.WithBlackouts(blackouts...), scheduler.WithWindows(allowed...))
+
+	job := func(ctx context.Context) error {
+		changed, err := reload(ctx)
+		if err != nil || adaptive == nil {
+			return err
+		}
+
+		if previous := adaptive.Interval(); adaptive.Observe(changed) != previous {
+			reloadInterval.WithLabelValues(name).Set(adaptive.Interval().Seconds())
+			// next reload is planned according to changed interval
+			return reloader.SetSchedule(name, adaptive)
+		}
+		return nil
+	}
+
+	return reloader.AddWithContext(scheduler.JobWithContextFunc(job), schedule, options...)
+}
@@ -0,0 +1,107 @@
+package idmappers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danielkraic/idmapper/scheduler"
+	"github.com/go-redis/redis"
+	"github.com/sirupsen/logrus"
+)
+
+// StateConfig configures persisting of reloader state, so reloads are planned according to last successful reloads after restart
+type StateConfig struct {
+	// Store is type of state store: none, file or redis
+	Store string `mapstructure:"store"`
+	// File is path of state file used by file store
+	File string `mapstructure:"file"`
+	// RedisKey is name of Redis hash used by redis store
+	RedisKey string `mapstructure:"redis_key"`
+}
+
+// newStore creates state store according to configuration, returns nil if state is not persisted
+func (config StateConfig) newStore(log *logrus.Logger, client *redis.Client) (scheduler.StateStore, error) {
+	var store scheduler.StateStore
+
+	switch config.Store {
+	case "", "none":
+		return nil, nil
+	case "file":
+		if config.File == "" {
+			return nil, fmt.Errorf("file of state store is not set")
+		}
+		store = scheduler.NewFileStateStore(config.File)
+	case "redis":
+		var err error
+		store, err = NewRedisStateStore(client, config.RedisKey)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown state store '%s'", config.Store)
+	}
+
+	return &loggingStateStore{log: log, store: store}, nil
+}
+
+// NewRedisStateStore creates scheduler.StateStore which stores state of jobs in Redis hash
+func NewRedisStateStore(client *redis.Client, hashName string) (scheduler.StateStore, error) {
+	if client == nil {
+		return nil, fmt.Errorf("failed to create Redis state store: redis client is nil")
+	}
+
+	return &redisStateStore{client: client, hashName: hashName}, nil
+}
+
+type redisStateStore struct {
+	client   *redis.Client
+	hashName string
+}
+
+func (store *redisStateStore) Load(name string) (time.Time, error) {
+	value, err := store.client.HGet(store.hashName, name).Result()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to HGET %s from hash %s: %s", name, store.hashName, err)
+	}
+
+	last, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid state of %s in hash %s: %s", name, store.hashName, err)
+	}
+
+	return last, nil
+}
+
+func (store *redisStateStore) Save(name string, lastSuccess time.Time) error {
+	err := store.client.HSet(store.hashName, name, lastSuccess.Format(time.RFC3339Nano)).Err()
+	if err != nil {
+		return fmt.Errorf("failed to HSET %s to hash %s: %s", name, store.hashName, err)
+	}
+
+	return nil
+}
+
+// loggingStateStore logs errors of store, scheduler ignores them
+type loggingStateStore struct {
+	log   *logrus.Logger
+	store scheduler.StateStore
+}
+
+func (store *loggingStateStore) Load(name string) (time.Time, error) {
+	last, err := store.store.Load(name)
+	if err != nil {
+		store.log.Warnf("failed to load reloader state of %s: %s", name, err)
+	}
+	return last, err
+}
+
+func (store *loggingStateStore) Save(name string, lastSuccess time.Time) error {
+	err := store.store.Save(name, lastSuccess)
+	if err != nil {
+		store.log.Warnf("failed to save reloader state of %s: %s", name, err)
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+package idmappers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danielkraic/idmapper/app/idmappers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisStateStore(t *testing.T) {
+	mr, client := newRedis(t)
+	defer mr.Close()
+
+	store, err := idmappers.NewRedisStateStore(client, "state")
+	assert.Nil(t, err)
+
+	last, err := store.Load("currency")
+	assert.Nil(t, err)
+	assert.True(t, last.IsZero())
+
+	now := time.Date(2020, 1, 1, 12, 30, 0, 5, time.UTC)
+	assert.Nil(t, store.Save("currency", now))
+	last, err = store.Load("currency")
+	assert.Nil(t, err)
+	assert.True(t, now.Equal(last))
+
+	mr.HSet("state", "country", "invalid")
+	_, err = store.Load("country")
+	assert.NotNil(t, err)
+
+	_, err = idmappers.NewRedisStateStore(nil, "state")
+	assert.NotNil(t, err)
+}
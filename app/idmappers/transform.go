@@ -0,0 +1,62 @@
+package idmappers
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/danielkraic/idmapper/idmapper"
+)
+
+// TransformConfig configuration of single step of declarative transform pipeline. Supported types:
+//
+//	trim             removes leading and trailing white space from ID and name
+//	lowercase_id     converts ID to lower case
+//	uppercase_id     converts ID to upper case
+//	normalize_name   converts name to Unicode Normalization Form C
+//	filter_id        keeps only values with ID matching regular expression pattern
+//	rename_id        replaces IDs found in table by their new IDs
+//	prefix_id        adds prefix to ID
+//	drop_empty_name  drops values with empty name
+type TransformConfig struct {
+	Type    string            `mapstructure:"type"`
+	Pattern string            `mapstructure:"pattern"`
+	Table   map[string]string `mapstructure:"table"`
+	Prefix  string            `mapstructure:"prefix"`
+}
+
+func newTransforms(configs []TransformConfig) ([]idmapper.Transform, error) {
+	transforms := make([]idmapper.Transform, 0, len(configs))
+
+	for i, config := range configs {
+		var transform idmapper.Transform
+
+		switch config.Type {
+		case "trim":
+			transform = idmapper.TrimSpace()
+		case "lowercase_id":
+			transform = idmapper.LowerID()
+		case "uppercase_id":
+			transform = idmapper.UpperID()
+		case "normalize_name":
+			transform = idmapper.NormalizeName()
+		case "filter_id":
+			pattern, err := regexp.Compile(config.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("transform %d (%s): invalid pattern: %s", i, config.Type, err)
+			}
+			transform = idmapper.FilterID(pattern)
+		case "rename_id":
+			transform = idmapper.RenameID(config.Table)
+		case "prefix_id":
+			transform = idmapper.PrefixID(config.Prefix)
+		case "drop_empty_name":
+			transform = idmapper.DropEmptyNames()
+		default:
+			return nil, fmt.Errorf("transform %d: unknown type '%s'", i, config.Type)
+		}
+
+		transforms = append(transforms, transform)
+	}
+
+	return transforms, nil
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/danielkraic/idmapper/app/handlers"
 	"github.com/danielkraic/idmapper/app/idmappers"
@@ -9,21 +10,52 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// CreateRouter creates http router
-func CreateRouter(apiPrefix string, appVersion *handlers.Version, idMappers *idmappers.IDMappers) *mux.Router {
+// CreateRouter creates http router. Maximal number of IDs in bulk lookup is not limited if maxBatchSize is zero
+func CreateRouter(apiPrefix string, appVersion *handlers.Version, idMappers *idmappers.IDMappers, maxBatchSize int, cache CacheConfig, readiness ReadinessConfig) *mux.Router {
 	r := mux.NewRouter()
 
 	versioned := func(route string) string {
 		return fmt.Sprintf("%s%s", apiPrefix, route)
 	}
 
-	r.Handle(versioned("/currency/{id}"), handlers.NewIDMapperHandler(idMappers.CurrencyCodes)).Methods("GET")
-	r.Handle(versioned("/country/{id}"), handlers.NewIDMapperHandler(idMappers.CountryCodes)).Methods("GET")
-	r.Handle(versioned("/language/{id}"), handlers.NewIDMapperHandler(idMappers.LanguageCodes)).Methods("GET")
+	for _, name := range idMappers.Names() {
+		idMapper, _ := idMappers.Mapper(name)
+		maxAge := cache.MaxAge(name)
+		r.Handle(versioned("/"+name), handlers.NewListHandler(name, idMapper, maxAge)).Methods("GET")
+		r.Handle(versioned("/"+name+"/_search"), handlers.NewSearchHandler(name, idMapper)).Methods("GET")
+		r.Handle(versioned("/"+name+"/_bulk"), handlers.NewBulkHandler(name, idMapper, maxBatchSize, maxAge)).Methods("GET", "POST")
+		r.Handle(versioned("/"+name+"/{id}"), handlers.NewIDMapperHandler(name, idMapper, maxAge)).Methods("GET")
+	}
 
 	r.Handle("/version", handlers.NewVersionHandler(appVersion)).Methods("GET")
 	r.HandleFunc("/health", handlers.HealthHandlerFunc).Methods("GET")
+	r.HandleFunc("/livez", handlers.LivenessHandlerFunc).Methods("GET")
+	r.Handle("/readyz", handlers.NewReadinessHandler(idMappers, idMappers.Clock(), readiness.Critical, readiness.MaxDataAge)).Methods("GET")
 	r.Handle("/metrics", promhttp.Handler())
 
+	r.NotFoundHandler = handlers.NewNotFoundHandler(apiPrefix, idMappers.Names())
+	r.MethodNotAllowedHandler = http.HandlerFunc(handlers.MethodNotAllowedHandlerFunc)
+
+	return r
+}
+
+// CreateAdminRouter creates http router of admin API managing IDMappers and their reload jobs
+func CreateAdminRouter(idMappers *idmappers.IDMappers) *mux.Router {
+	r := mux.NewRouter()
+
+	r.Handle("/admin/mappers", handlers.NewMappersHandler(idMappers)).Methods("GET")
+	r.Handle("/admin/mappers/reload", handlers.NewReloadHandler(idMappers)).Methods("POST")
+	r.Handle("/admin/mappers/{name}/reload", handlers.NewReloadHandler(idMappers)).Methods("POST")
+	r.Handle("/admin/mappers/{name}/snapshot", handlers.NewSnapshotHandler(idMappers)).Methods("GET")
+
+	r.Handle("/admin/jobs", handlers.NewJobsHandler(idMappers)).Methods("GET")
+	r.Handle("/admin/jobs/{name}/run", handlers.NewJobActionHandler(idMappers, idMappers.ForceRunJob)).Methods("POST").Queries("force", "true")
+	r.Handle("/admin/jobs/{name}/run", handlers.NewJobActionHandler(idMappers, idMappers.RunJob)).Methods("POST")
+	r.Handle("/admin/jobs/{name}/pause", handlers.NewJobActionHandler(idMappers, idMappers.PauseJob)).Methods("POST")
+	r.Handle("/admin/jobs/{name}/resume", handlers.NewJobActionHandler(idMappers, idMappers.ResumeJob)).Methods("POST")
+
+	r.NotFoundHandler = handlers.NewNotFoundHandler("", nil)
+	r.MethodNotAllowedHandler = http.HandlerFunc(handlers.MethodNotAllowedHandlerFunc)
+
 	return r
 }
@@ -2,31 +2,63 @@ package app
 
 import (
 	"context"
-	"log"
 	"net/http"
 	"os"
+	"strings"
+
+	"github.com/danielkraic/idmapper/app/handlers"
 )
 
 // Run starts app by running http server
 func (app *App) Run(done chan os.Signal) {
 	app.IDMappers.RunReloader(app.log)
-	defer app.IDMappers.StopReloader()
+	err := app.IDMappers.RunBackendChecks(app.Configuration.Readiness.BackendInterval, app.Configuration.Readiness.BackendTimeout)
+	if err != nil {
+		app.log.Errorf("setup of checks of backends failed: %s", err)
+	}
 
-	httpServer := &http.Server{
-		Addr:    app.Configuration.Addr,
-		Handler: CreateRouter(app.Configuration.APIPrefix, app.Version, app.IDMappers),
+	router := CreateRouter(app.Configuration.APIPrefix, app.Version, app.IDMappers, app.Configuration.MaxBatchSize, app.Configuration.CacheMaxAge, app.Configuration.Readiness)
+	adminRouter := CreateAdminRouter(app.IDMappers)
+
+	servers := []*http.Server{}
+	if app.Configuration.AdminAddr == "" {
+		router.PathPrefix("/admin/").Handler(adminRouter)
+	} else {
+		servers = append(servers, &http.Server{
+			Addr:    app.Configuration.AdminAddr,
+			Handler: handlers.WithRequestID(adminRouter),
+		})
 	}
+	servers = append(servers, &http.Server{
+		Addr:    app.Configuration.Addr,
+		Handler: handlers.WithRequestID(router),
+	})
 
-	go func() {
-		app.log.Infof("Starting server on %s", app.Configuration.Addr)
-		app.log.Fatal(httpServer.ListenAndServe())
-	}()
+	for _, httpServer := range servers {
+		go func(httpServer *http.Server) {
+			app.log.Infof("Starting server on %s", httpServer.Addr)
+			err := httpServer.ListenAndServe()
+			if err != http.ErrServerClosed {
+				app.log.Fatal(err)
+			}
+		}(httpServer)
+	}
 
 	<-done
 	app.log.Info("Shutdown signal received. Exiting.")
 
-	err := httpServer.Shutdown(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), app.Configuration.ShutdownTimeout)
+	defer cancel()
+
+	for _, httpServer := range servers {
+		err := httpServer.Shutdown(ctx)
+		if err != nil {
+			app.log.Errorf("failed to shutdown http server on %s: %s", httpServer.Addr, err)
+		}
+	}
+
+	running, err := app.IDMappers.ShutdownReloader(ctx)
 	if err != nil {
-		log.Fatalf("failed to shutdown http server: %s", err)
+		app.log.Warnf("failed to wait for IDMappers reloads to finish: %s, still running: %s", err, strings.Join(running, ", "))
 	}
 }
@@ -0,0 +1,43 @@
+// Package clock provides abstraction of time used by scheduler and idmapper, so they can be tested with fake time
+package clock
+
+import "time"
+
+// Clock provides current time and timers
+type Clock interface {
+	Now() time.Time
+	// NewTimer creates timer that sends current time on its channel after at least duration d
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is single event timer created by Clock
+type Timer interface {
+	C() <-chan time.Time
+	// Stop prevents timer from firing. Returns false if timer already fired or was stopped
+	Stop() bool
+}
+
+// Real is Clock using system time
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (timer realTimer) C() <-chan time.Time {
+	return timer.timer.C
+}
+
+func (timer realTimer) Stop() bool {
+	return timer.timer.Stop()
+}
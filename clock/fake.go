@@ -0,0 +1,123 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is Clock which time is changed only manually by Set or Advance. May be shared between goroutines.
+type Fake struct {
+	mtx    sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake creates Fake clock set to given time
+func NewFake(now time.Time) *Fake {
+	fake := &Fake{now: now}
+	fake.cond = sync.NewCond(&fake.mtx)
+	return fake
+}
+
+// Now returns current time of fake clock
+func (fake *Fake) Now() time.Time {
+	fake.mtx.Lock()
+	defer fake.mtx.Unlock()
+	return fake.now
+}
+
+// NewTimer creates timer that fires when fake clock is advanced by at least d. Timer with non-positive duration fires immediately
+func (fake *Fake) NewTimer(d time.Duration) Timer {
+	fake.mtx.Lock()
+	defer fake.mtx.Unlock()
+
+	timer := &fakeTimer{
+		fake: fake,
+		when: fake.now.Add(d),
+		c:    make(chan time.Time, 1),
+	}
+
+	if d <= 0 {
+		timer.c <- fake.now
+		return timer
+	}
+
+	fake.timers = append(fake.timers, timer)
+	fake.cond.Broadcast()
+	return timer
+}
+
+// Advance moves fake clock forward by d and fires timers due in order of their times
+func (fake *Fake) Advance(d time.Duration) {
+	fake.Set(fake.Now().Add(d))
+}
+
+// Set sets fake clock to given time and fires timers due in order of their times
+func (fake *Fake) Set(now time.Time) {
+	fake.mtx.Lock()
+	defer fake.mtx.Unlock()
+
+	fake.now = now
+
+	sort.Slice(fake.timers, func(i, j int) bool {
+		return fake.timers[i].when.Before(fake.timers[j].when)
+	})
+
+	pending := fake.timers[:0]
+	for _, timer := range fake.timers {
+		if timer.when.After(now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- timer.when
+	}
+	fake.timers = pending
+	fake.cond.Broadcast()
+}
+
+// Timers returns number of timers waiting to fire
+func (fake *Fake) Timers() int {
+	fake.mtx.Lock()
+	defer fake.mtx.Unlock()
+	return len(fake.timers)
+}
+
+// BlockUntil blocks until at least n timers are waiting to fire. It is used to wait for goroutines to plan their work before clock is advanced
+func (fake *Fake) BlockUntil(n int) {
+	fake.mtx.Lock()
+	defer fake.mtx.Unlock()
+
+	for len(fake.timers) < n {
+		fake.cond.Wait()
+	}
+}
+
+func (fake *Fake) stop(timer *fakeTimer) bool {
+	fake.mtx.Lock()
+	defer fake.mtx.Unlock()
+
+	for i, pending := range fake.timers {
+		if pending == timer {
+			fake.timers = append(fake.timers[:i], fake.timers[i+1:]...)
+			fake.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	fake *Fake
+	when time.Time
+	c    chan time.Time
+}
+
+func (timer *fakeTimer) C() <-chan time.Time {
+	return timer.c
+}
+
+func (timer *fakeTimer) Stop() bool {
+	return timer.fake.stop(timer)
+}
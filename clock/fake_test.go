@@ -0,0 +1,67 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danielkraic/idmapper/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	assert.Equal(t, start, fake.Now())
+
+	second := fake.NewTimer(2 * time.Second)
+	first := fake.NewTimer(time.Second)
+	stopped := fake.NewTimer(time.Second)
+	assert.Equal(t, 3, fake.Timers())
+
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+
+	fake.Advance(1500 * time.Millisecond)
+	assert.Equal(t, start.Add(1500*time.Millisecond), fake.Now())
+	assert.Equal(t, start.Add(time.Second), <-first.C())
+	assert.False(t, first.Stop())
+	assert.Equal(t, 1, fake.Timers())
+
+	select {
+	case <-second.C():
+		t.Error("timer fired before its time")
+	default:
+	}
+
+	fake.Advance(time.Second)
+	assert.Equal(t, start.Add(2*time.Second), <-second.C())
+	assert.Equal(t, 0, fake.Timers())
+	assert.Equal(t, 0, len(stopped.C()))
+
+	// timer with non-positive duration fires immediately
+	assert.Equal(t, fake.Now(), <-fake.NewTimer(0).C())
+}
+
+func TestFakeBlockUntil(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+
+	fired := make(chan struct{})
+	go func() {
+		<-fake.NewTimer(time.Minute).C()
+		close(fired)
+	}()
+
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+	<-fired
+}
+
+func TestReal(t *testing.T) {
+	before := time.Now()
+	now := clock.Real.Now()
+	assert.False(t, now.Before(before))
+
+	timer := clock.Real.NewTimer(time.Millisecond)
+	assert.False(t, (<-timer.C()).Before(now))
+	assert.False(t, timer.Stop())
+}
@@ -0,0 +1,125 @@
+// Command isogen generates Go source with embedded ISO datasets used by package iso.
+//
+// Input files are JSON files in format of iso-codes project (https://salsa.debian.org/iso-codes-team/iso-codes),
+// usually installed in /usr/share/iso-codes/json:
+//
+//	iso_3166-1.json  countries
+//	iso_4217.json    currencies
+//	iso_639-2.json   languages
+//
+// Usage:
+//
+//	go run ./cmd/isogen -dir /usr/share/iso-codes/json -out iso/data.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+type entry struct {
+	Alpha2  string `json:"alpha_2"`
+	Alpha3  string `json:"alpha_3"`
+	Numeric string `json:"numeric"`
+	Name    string `json:"name"`
+}
+
+type dataset struct {
+	// Var is name of generated variable
+	Var string
+	// File is name of input file in iso-codes json directory
+	File string
+	// Key is name of top level key in input file
+	Key string
+	// Standard is name of ISO standard for generated comment
+	Standard string
+	Entries  []entry
+}
+
+var outputTemplate = template.Must(template.New("data").Parse(`// Code generated by isogen. DO NOT EDIT.
+
+package iso
+
+{{range .}}
+// {{.Var}} is snapshot of {{.Standard}} ({{len .Entries}} entries)
+var {{.Var}} = []Entry{
+{{- range .Entries}}
+	{Alpha2: {{printf "%q" .Alpha2}}, Alpha3: {{printf "%q" .Alpha3}}, Numeric: {{printf "%q" .Numeric}}, Name: {{printf "%q" .Name}}},
+{{- end}}
+}
+{{end}}
+`))
+
+func main() {
+	dir := flag.String("dir", "/usr/share/iso-codes/json", "directory with iso-codes json files")
+	out := flag.String("out", "data.go", "path to generated go file")
+	flag.Parse()
+
+	datasets := []*dataset{
+		{Var: "countries", File: "iso_3166-1.json", Key: "3166-1", Standard: "ISO 3166-1"},
+		{Var: "currencies", File: "iso_4217.json", Key: "4217", Standard: "ISO 4217"},
+		{Var: "languages", File: "iso_639-2.json", Key: "639-2", Standard: "ISO 639-1 and ISO 639-2"},
+	}
+
+	for _, ds := range datasets {
+		entries, err := readEntries(filepath.Join(*dir, ds.File), ds.Key)
+		if err != nil {
+			log.Fatal(err)
+		}
+		ds.Entries = entries
+	}
+
+	var buf bytes.Buffer
+	err := outputTemplate.Execute(&buf, datasets)
+	if err != nil {
+		log.Fatalf("failed to execute template: %s", err)
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format generated source: %s", err)
+	}
+
+	err = ioutil.WriteFile(*out, source, 0644)
+	if err != nil {
+		log.Fatalf("failed to write %s: %s", *out, err)
+	}
+}
+
+func readEntries(path string, key string) ([]entry, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", path, err)
+	}
+
+	var content map[string][]entry
+	err = json.Unmarshal(data, &content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode json from %s: %s", path, err)
+	}
+
+	entries, found := content[key]
+	if !found {
+		return nil, fmt.Errorf("key %s not found in %s", key, path)
+	}
+
+	for i := range entries {
+		// ISO 639 lists alternative names separated by semicolon, keep the first one
+		entries[i].Name = strings.TrimSpace(strings.Split(entries[i].Name, ";")[0])
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Alpha3 < entries[j].Alpha3
+	})
+
+	return entries, nil
+}
@@ -1,45 +1,264 @@
 package idmapper
 
-import "sync"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/danielkraic/idmapper/clock"
+)
 
 // ValuesMap map of values where map key is values' ID and map value is value's name
 type ValuesMap map[string]string
 
 // IDMapper object for mapping values' ID and name. May be shared between goroutines.
 type IDMapper struct {
-	source SourceReader
-	values ValuesMap
-	mtx    sync.Mutex
+	source      SourceReader
+	normalizers []Normalizer
+	aliases     map[string]string
+	clock       clock.Clock
+	values      ValuesMap
+	loadedAt    time.Time
+	modifiedAt  time.Time
+	hash        string
+	index       *searchIndex
+	mtx         sync.Mutex
+}
+
+// Option configures IDMapper
+type Option func(*IDMapper)
+
+// WithNormalizers sets normalizers applied in given order to IDs of values read from source and to IDs passed to Get and Lookup
+func WithNormalizers(normalizers ...Normalizer) Option {
+	return func(idMapper *IDMapper) {
+		idMapper.normalizers = append(idMapper.normalizers, normalizers...)
+	}
+}
+
+// WithAliases sets table of alternative IDs mapped to canonical IDs, applied after normalizers to IDs of values read
+// from source and to IDs passed to Get and Lookup. Aliases and canonical IDs in table are converted by normalizers.
+// Value read from source with alias ID is dropped if source contains also value with canonical ID
+func WithAliases(aliases map[string]string) Option {
+	return func(idMapper *IDMapper) {
+		if idMapper.aliases == nil {
+			idMapper.aliases = make(map[string]string, len(aliases))
+		}
+		for alias, canonical := range aliases {
+			idMapper.aliases[alias] = canonical
+		}
+	}
+}
+
+// WithClock sets clock used to timestamp loaded values, real clock is used by default
+func WithClock(c clock.Clock) Option {
+	return func(idMapper *IDMapper) {
+		idMapper.clock = c
+	}
 }
 
 // NewIDMapper creates new IDMapper and load values using SourceReader
-func NewIDMapper(source SourceReader) (*IDMapper, error) {
+func NewIDMapper(source SourceReader, options ...Option) (*IDMapper, error) {
 	idMapper := &IDMapper{
 		source: source,
+		clock:  clock.Real,
 		values: make(ValuesMap),
 	}
 
+	for _, option := range options {
+		option(idMapper)
+	}
+
+	// aliases are matched against normalized IDs, so they are normalized too
+	aliases := make(map[string]string, len(idMapper.aliases))
+	for alias, canonical := range idMapper.aliases {
+		aliases[idMapper.applyNormalizers(alias)] = idMapper.applyNormalizers(canonical)
+	}
+	idMapper.aliases = aliases
+
 	return idMapper, idMapper.Reload()
 }
 
 // Get gets value's name for given ID. Return value is pair of value's name and boolean if value was found
 func (idMapper *IDMapper) Get(id string) (string, bool) {
+	_, result, found := idMapper.Lookup(id)
+	return result, found
+}
+
+// Lookup gets canonical form of given ID and value's name. Return value is canonical ID, value's name and boolean if value was found
+func (idMapper *IDMapper) Lookup(id string) (string, string, bool) {
+	id = idMapper.normalize(id)
+
 	idMapper.mtx.Lock()
 	defer idMapper.mtx.Unlock()
 	result, found := idMapper.values[id]
-	return result, found
+	return id, result, found
+}
+
+// GetMany gets values' names for given IDs from one snapshot of values. Return value is map of found values
+// by their canonical IDs and list of IDs that were not found, in order they were given
+func (idMapper *IDMapper) GetMany(ids []string) (ValuesMap, []string) {
+	canonical := make([]string, len(ids))
+	for i, id := range ids {
+		canonical[i] = idMapper.normalize(id)
+	}
+
+	found := make(ValuesMap, len(ids))
+	var missing []string
+
+	idMapper.mtx.Lock()
+	defer idMapper.mtx.Unlock()
+	for i, id := range canonical {
+		if name, ok := idMapper.values[id]; ok {
+			found[id] = name
+		} else {
+			missing = append(missing, ids[i])
+		}
+	}
+
+	return found, missing
+}
+
+// Search finds values which ID equals to query or which name matches query by prefix, substring or fuzzily,
+// ignoring case and accents. Results are ordered by score, at most limit results are returned if limit is positive
+func (idMapper *IDMapper) Search(query string, limit int) []SearchResult {
+	idMapper.mtx.Lock()
+	index := idMapper.index
+	idMapper.mtx.Unlock()
+
+	if index == nil {
+		return nil
+	}
+	return index.search(query, limit)
 }
 
 // Reload reloads id mapper values using SourceReader
 func (idMapper *IDMapper) Reload() error {
-	newValues, err := idMapper.source.Read()
+	return idMapper.ReloadContext(context.Background())
+}
+
+// ReloadContext reloads id mapper values using SourceReader. Values are not changed if context is done before reading finishes
+func (idMapper *IDMapper) ReloadContext(ctx context.Context) error {
+	newValues, err := ReadContext(ctx, idMapper.source)
 	if err != nil {
 		return err
 	}
 
+	newValues = idMapper.normalizeValues(newValues)
+	hash := hashValues(newValues)
+	index := newSearchIndex(newValues)
+
+	loadedAt := idMapper.clock.Now()
+
 	idMapper.mtx.Lock()
 	defer idMapper.mtx.Unlock()
+	if hash != idMapper.hash {
+		idMapper.modifiedAt = loadedAt
+	}
 	idMapper.values = newValues
+	idMapper.loadedAt = loadedAt
+	idMapper.hash = hash
+	idMapper.index = index
 
 	return nil
 }
+
+// LoadedAt returns time of last successful load of values, zero if values were not loaded yet
+func (idMapper *IDMapper) LoadedAt() time.Time {
+	idMapper.mtx.Lock()
+	defer idMapper.mtx.Unlock()
+	return idMapper.loadedAt
+}
+
+// ModifiedAt returns time of last load which changed values, zero if values were not loaded yet
+func (idMapper *IDMapper) ModifiedAt() time.Time {
+	idMapper.mtx.Lock()
+	defer idMapper.mtx.Unlock()
+	return idMapper.modifiedAt
+}
+
+// Snapshot returns currently loaded values and their version, which is their content hash (see Hash).
+// Returned values are replaced, not modified, by reload and must not be modified by caller
+func (idMapper *IDMapper) Snapshot() (ValuesMap, string) {
+	idMapper.mtx.Lock()
+	defer idMapper.mtx.Unlock()
+	return idMapper.values, idMapper.hash
+}
+
+// Hash returns content hash of loaded values, it changes only if loaded values change. Empty if values were not loaded yet
+func (idMapper *IDMapper) Hash() string {
+	idMapper.mtx.Lock()
+	defer idMapper.mtx.Unlock()
+	return idMapper.hash
+}
+
+// hashValues returns SHA-256 of values in order of their IDs
+func hashValues(values ValuesMap) string {
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	hash := sha256.New()
+	for _, id := range ids {
+		hash.Write([]byte(id))
+		hash.Write([]byte{0})
+		hash.Write([]byte(values[id]))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// normalize converts ID to canonical form by normalizers and aliases
+func (idMapper *IDMapper) normalize(id string) string {
+	id = idMapper.applyNormalizers(id)
+	if canonical, found := idMapper.aliases[id]; found {
+		return canonical
+	}
+	return id
+}
+
+func (idMapper *IDMapper) applyNormalizers(id string) string {
+	for _, normalizer := range idMapper.normalizers {
+		id = normalizer(id)
+	}
+	return id
+}
+
+// normalizeValues converts IDs of values to canonical form.
+// Values are processed in order of their original IDs, if more values have the same canonical ID, the last one is kept.
+// Values with alias IDs are kept only if there is no value with canonical ID
+func (idMapper *IDMapper) normalizeValues(values ValuesMap) ValuesMap {
+	if len(idMapper.normalizers) == 0 && len(idMapper.aliases) == 0 {
+		return values
+	}
+
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	result := make(ValuesMap, len(values))
+	aliased := make(ValuesMap)
+	for _, id := range ids {
+		normalized := idMapper.applyNormalizers(id)
+		if canonical, found := idMapper.aliases[normalized]; found {
+			aliased[canonical] = values[id]
+		} else {
+			result[normalized] = values[id]
+		}
+	}
+
+	for id, name := range aliased {
+		if _, found := result[id]; !found {
+			result[id] = name
+		}
+	}
+
+	return result
+}
@@ -1,9 +1,12 @@
 package idmapper_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/danielkraic/idmapper/clock"
 	"github.com/danielkraic/idmapper/idmapper"
 	"github.com/stretchr/testify/assert"
 )
@@ -123,3 +126,142 @@ func TestIdMapperReload(t *testing.T) {
 
 	assert.Equal(t, source.CallCount, 2)
 }
+
+func TestIdMapperReloadContextCancelled(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{"a": "A"},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(source)
+	assert.Nil(t, err)
+
+	source.values = idmapper.ValuesMap{"b": "B"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = idMapper.ReloadContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+
+	// values read after context was cancelled are discarded
+	_, found := idMapper.Get("a")
+	assert.True(t, found)
+	_, found = idMapper.Get("b")
+	assert.False(t, found)
+}
+
+func TestIdMapperLoadedAt(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFake(start)
+
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{"a": "A"},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(source, idmapper.WithClock(fakeClock))
+	assert.Nil(t, err)
+	assert.Equal(t, start, idMapper.LoadedAt())
+
+	fakeClock.Advance(time.Hour)
+	err = idMapper.Reload()
+	assert.Nil(t, err)
+	assert.Equal(t, start.Add(time.Hour), idMapper.LoadedAt())
+
+	// failed reload does not change time of last load
+	fakeClock.Advance(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = idMapper.ReloadContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, start.Add(time.Hour), idMapper.LoadedAt())
+}
+
+func TestIdMapperModifiedAt(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFake(start)
+
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{"a": "A"},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(source, idmapper.WithClock(fakeClock))
+	assert.Nil(t, err)
+	assert.Equal(t, start, idMapper.ModifiedAt())
+
+	// reload of unchanged values does not change time of modification
+	fakeClock.Advance(time.Hour)
+	err = idMapper.Reload()
+	assert.Nil(t, err)
+	assert.Equal(t, start.Add(time.Hour), idMapper.LoadedAt())
+	assert.Equal(t, start, idMapper.ModifiedAt())
+
+	fakeClock.Advance(time.Hour)
+	source.values = idmapper.ValuesMap{"a": "A", "b": "B"}
+	err = idMapper.Reload()
+	assert.Nil(t, err)
+	assert.Equal(t, start.Add(2*time.Hour), idMapper.ModifiedAt())
+}
+
+func TestIdMapperHash(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{"a": "A", "b": "B"},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(source)
+	assert.Nil(t, err)
+	hash := idMapper.Hash()
+	assert.NotEmpty(t, hash)
+
+	err = idMapper.Reload()
+	assert.Nil(t, err)
+	assert.Equal(t, hash, idMapper.Hash())
+
+	source.values = idmapper.ValuesMap{"a": "A", "b": "C"}
+	err = idMapper.Reload()
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, idMapper.Hash())
+
+	// separators prevent collisions of concatenated values
+	source.values = idmapper.ValuesMap{"a": "AbB"}
+	err = idMapper.Reload()
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, idMapper.Hash())
+}
+
+func TestIdMapperGetMany(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{"a": "A", "b": "B"},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(source, idmapper.WithNormalizers(idmapper.FoldCase()))
+	assert.Nil(t, err)
+
+	found, missing := idMapper.GetMany([]string{"A", "x", "b", "Y"})
+	assert.Equal(t, idmapper.ValuesMap{"a": "A", "b": "B"}, found)
+	assert.Equal(t, []string{"x", "Y"}, missing)
+
+	found, missing = idMapper.GetMany(nil)
+	assert.Empty(t, found)
+	assert.Empty(t, missing)
+}
+
+func TestIdMapperSnapshot(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{"a": "A"},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(source)
+	assert.Nil(t, err)
+
+	values, version := idMapper.Snapshot()
+	assert.Equal(t, idmapper.ValuesMap{"a": "A"}, values)
+	assert.Equal(t, idMapper.Hash(), version)
+
+	// reload does not modify returned snapshot
+	source.values = idmapper.ValuesMap{"b": "B"}
+	assert.Nil(t, idMapper.Reload())
+	assert.Equal(t, idmapper.ValuesMap{"a": "A"}, values)
+
+	values, newVersion := idMapper.Snapshot()
+	assert.Equal(t, idmapper.ValuesMap{"b": "B"}, values)
+	assert.NotEqual(t, version, newVersion)
+}
@@ -0,0 +1,25 @@
+package idmapper
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer converts ID to its canonical form
+type Normalizer func(id string) string
+
+// FoldCase converts ID to lower case
+func FoldCase() Normalizer {
+	return strings.ToLower
+}
+
+// TrimID removes leading and trailing white space from ID
+func TrimID() Normalizer {
+	return strings.TrimSpace
+}
+
+// NormalizeID converts ID to Unicode Normalization Form C
+func NormalizeID() Normalizer {
+	return norm.NFC.String
+}
@@ -0,0 +1,126 @@
+package idmapper_test
+
+import (
+	"testing"
+
+	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdMapperNormalizers(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{
+			"SK":  "Slovakia",
+			" cz": "Czechia",
+			"gb":  "United Kingdom",
+		},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(source, idmapper.WithNormalizers(
+		idmapper.TrimID(),
+		idmapper.NormalizeID(),
+		idmapper.FoldCase(),
+	), idmapper.WithAliases(map[string]string{"uk": "gb"}))
+	assert.Nil(t, err)
+
+	tests := []struct {
+		id          string
+		canonicalID string
+		name        string
+	}{
+		{"sk", "sk", "Slovakia"},
+		{"SK", "sk", "Slovakia"},
+		{" Sk ", "sk", "Slovakia"},
+		{"CZ", "cz", "Czechia"},
+		{"UK", "gb", "United Kingdom"},
+		{"gb", "gb", "United Kingdom"},
+	}
+
+	for _, test := range tests {
+		canonicalID, name, found := idMapper.Lookup(test.id)
+		assert.True(t, found, test.id)
+		assert.Equal(t, test.canonicalID, canonicalID)
+		assert.Equal(t, test.name, name)
+
+		name, found = idMapper.Get(test.id)
+		assert.True(t, found, test.id)
+		assert.Equal(t, test.name, name)
+	}
+
+	canonicalID, _, found := idMapper.Lookup(" XX")
+	assert.False(t, found)
+	assert.Equal(t, "xx", canonicalID)
+}
+
+func TestIdMapperNormalizersCollision(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{
+			"sk": "first",
+			"SK": "second",
+		},
+	}
+
+	// values are processed in order of original IDs ("SK", "sk"), last one is kept
+	idMapper, err := idmapper.NewIDMapper(source, idmapper.WithNormalizers(idmapper.FoldCase()))
+	assert.Nil(t, err)
+
+	name, found := idMapper.Get("Sk")
+	assert.True(t, found)
+	assert.Equal(t, "first", name)
+}
+
+func TestIdMapperNormalizedAliases(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{
+			"gb": "United Kingdom",
+		},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(source,
+		idmapper.WithAliases(map[string]string{" UK": "GB"}),
+		idmapper.WithNormalizers(idmapper.TrimID(), idmapper.FoldCase()),
+	)
+	assert.Nil(t, err)
+
+	for _, id := range []string{"UK", "uk", " Uk ", "GB"} {
+		canonicalID, name, found := idMapper.Lookup(id)
+		assert.True(t, found, id)
+		assert.Equal(t, "gb", canonicalID)
+		assert.Equal(t, "United Kingdom", name)
+	}
+}
+
+func TestIdMapperAliasesInSource(t *testing.T) {
+	tests := []idmapper.ValuesMap{
+		{"uk": "Great Britain", "gb": "United Kingdom"},
+		{"UK": "Great Britain", "gb": "United Kingdom"},
+		{"uk": "Great Britain", "GB": "United Kingdom"},
+	}
+
+	// value with canonical ID is kept regardless of order of IDs, value with alias ID is dropped
+	for _, values := range tests {
+		idMapper, err := idmapper.NewIDMapper(&TestingSourceValid{values: values},
+			idmapper.WithNormalizers(idmapper.FoldCase()),
+			idmapper.WithAliases(map[string]string{"uk": "gb"}),
+		)
+		assert.Nil(t, err)
+
+		snapshot, _ := idMapper.Snapshot()
+		assert.Equal(t, idmapper.ValuesMap{"gb": "United Kingdom"}, snapshot)
+	}
+
+	// value with alias ID is used if there is no value with canonical ID
+	idMapper, err := idmapper.NewIDMapper(&TestingSourceValid{values: idmapper.ValuesMap{"uk": "Great Britain"}},
+		idmapper.WithAliases(map[string]string{"uk": "gb"}),
+	)
+	assert.Nil(t, err)
+
+	canonicalID, name, found := idMapper.Lookup("uk")
+	assert.True(t, found)
+	assert.Equal(t, "gb", canonicalID)
+	assert.Equal(t, "Great Britain", name)
+}
+
+func TestNormalizeID(t *testing.T) {
+	assert.Equal(t, "\u00e9", idmapper.NormalizeID()("e\u0301"))
+}
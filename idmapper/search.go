@@ -0,0 +1,250 @@
+package idmapper
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// minFuzzySimilarity is minimal trigram similarity of name and query matched fuzzily
+const minFuzzySimilarity = 0.3
+
+// Scores of search results by kind of match, fuzzy matches score below SubstringScore according to their similarity
+const (
+	// IDScore is score of value which ID equals to query
+	IDScore = 1.0
+	// NameScore is score of value which name equals to query
+	NameScore = 0.9
+	// PrefixScore is score of value which name starts with query
+	PrefixScore = 0.8
+	// WordPrefixScore is score of value which word of name starts with query
+	WordPrefixScore = 0.7
+	// SubstringScore is score of value which name contains query
+	SubstringScore = 0.6
+)
+
+// SearchResult is value found by Search
+type SearchResult struct {
+	ID    string
+	Name  string
+	Score float64
+}
+
+// searchEntry is indexed value with folded name
+type searchEntry struct {
+	id     string
+	name   string
+	folded string
+}
+
+// searchIndex indexes names of values for prefix, substring and fuzzy search. Names are folded to lower case
+// without accents and punctuation. It is immutable once built
+type searchIndex struct {
+	entries []searchEntry
+	ids     map[string]int
+	// words is prefix trie of whole folded names and their words
+	words *trieNode
+	// trigrams maps trigrams of folded names to entries containing them
+	trigrams map[string][]int
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	// entries are entries with word ending at the node
+	entries []int
+}
+
+// fold converts text to lower case, removes accents and replaces other characters than letters and digits by single space
+func fold(text string) string {
+	var builder strings.Builder
+	space := true
+	for _, r := range norm.NFD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			builder.WriteRune(unicode.ToLower(r))
+			space = false
+		case !space:
+			builder.WriteRune(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// trigrams returns distinct trigrams of folded text padded by spaces
+func trigrams(folded string) []string {
+	runes := []rune("  " + folded + " ")
+	seen := make(map[string]bool, len(runes))
+	result := make([]string, 0, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		trigram := string(runes[i : i+3])
+		if !seen[trigram] {
+			seen[trigram] = true
+			result = append(result, trigram)
+		}
+	}
+	return result
+}
+
+func newSearchIndex(values ValuesMap) *searchIndex {
+	index := &searchIndex{
+		entries:  make([]searchEntry, 0, len(values)),
+		ids:      make(map[string]int, len(values)),
+		words:    &trieNode{},
+		trigrams: make(map[string][]int),
+	}
+
+	for id, name := range values {
+		index.entries = append(index.entries, searchEntry{id: id, name: name, folded: fold(name)})
+	}
+	// entries are ordered, so results with equal score are ordered deterministically
+	sort.Slice(index.entries, func(i, j int) bool {
+		return index.entries[i].id < index.entries[j].id
+	})
+
+	for i, entry := range index.entries {
+		index.ids[fold(entry.id)] = i
+		index.words.insert(entry.folded, i)
+		for _, word := range strings.Fields(entry.folded) {
+			index.words.insert(word, i)
+		}
+		for _, trigram := range trigrams(entry.folded) {
+			index.trigrams[trigram] = append(index.trigrams[trigram], i)
+		}
+	}
+
+	return index
+}
+
+func (node *trieNode) insert(word string, entry int) {
+	for _, r := range word {
+		child, found := node.children[r]
+		if !found {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+			child = &trieNode{}
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.entries = append(node.entries, entry)
+}
+
+// collect calls fn for entries of node and all its descendants
+func (node *trieNode) collect(fn func(entry int)) {
+	for _, entry := range node.entries {
+		fn(entry)
+	}
+	for _, child := range node.children {
+		child.collect(fn)
+	}
+}
+
+// find returns node of given prefix, nil if there is no word with the prefix
+func (node *trieNode) find(prefix string) *trieNode {
+	for _, r := range prefix {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// search returns values matching query ordered by score, at most limit values if limit is positive
+func (index *searchIndex) search(query string, limit int) []SearchResult {
+	folded := fold(query)
+	if folded == "" {
+		return nil
+	}
+
+	scores := make(map[int]float64)
+	match := func(entry int, score float64) {
+		if score > scores[entry] {
+			scores[entry] = score
+		}
+	}
+
+	if entry, found := index.ids[folded]; found {
+		match(entry, IDScore)
+	}
+
+	if node := index.words.find(folded); node != nil {
+		node.collect(func(entry int) {
+			switch {
+			case index.entries[entry].folded == folded:
+				match(entry, NameScore)
+			case strings.HasPrefix(index.entries[entry].folded, folded):
+				match(entry, PrefixScore)
+			default:
+				match(entry, WordPrefixScore)
+			}
+		})
+	}
+
+	// short query shares no trigrams with names containing it inside of word, so all names are scanned for it
+	if utf8.RuneCountInString(folded) < 3 {
+		for entry := range index.entries {
+			if _, found := scores[entry]; !found && strings.Contains(index.entries[entry].folded, folded) {
+				match(entry, SubstringScore)
+			}
+		}
+		return index.results(scores, limit)
+	}
+
+	// candidates of substring and fuzzy matches share trigrams with query
+	queryTrigrams := trigrams(folded)
+	shared := make(map[int]int)
+	for _, trigram := range queryTrigrams {
+		for _, entry := range index.trigrams[trigram] {
+			shared[entry]++
+		}
+	}
+	for entry, count := range shared {
+		if _, found := scores[entry]; found {
+			continue
+		}
+		if strings.Contains(index.entries[entry].folded, folded) {
+			match(entry, SubstringScore)
+			continue
+		}
+		// Dice coefficient of trigram sets
+		similarity := 2 * float64(count) / float64(len(queryTrigrams)+len(trigrams(index.entries[entry].folded)))
+		if similarity >= minFuzzySimilarity {
+			match(entry, SubstringScore*similarity)
+		}
+	}
+
+	return index.results(scores, limit)
+}
+
+// results returns matched entries with their scores ordered by score, at most limit entries if limit is positive
+func (index *searchIndex) results(scores map[int]float64, limit int) []SearchResult {
+	results := make([]SearchResult, 0, len(scores))
+	for entry, score := range scores {
+		results = append(results, SearchResult{ID: index.entries[entry].id, Name: index.entries[entry].name, Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
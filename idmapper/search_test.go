@@ -0,0 +1,77 @@
+package idmapper_test
+
+import (
+	"testing"
+
+	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/stretchr/testify/assert"
+)
+
+func searchIDs(results []idmapper.SearchResult) []string {
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+	return ids
+}
+
+func TestIdMapperSearch(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{
+			"sk": "Slovakia",
+			"si": "Slovenia",
+			"cz": "Czechia",
+			"ie": "Ireland",
+			"gb": "United Kingdom of Great Britain and Northern Ireland",
+			"re": "Réunion",
+			"us": "United States",
+		},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(source)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		query    string
+		limit    int
+		expected []string
+	}{
+		// prefix of name
+		{"slov", 0, []string{"sk", "si"}},
+		{"SLOVE", 0, []string{"si", "sk"}},
+		// exact id ranks before names
+		{"sk", 0, []string{"sk"}},
+		// exact name ranks before prefix of name and prefix of word
+		{"ireland", 0, []string{"ie", "gb"}},
+		// accent-insensitive
+		{"reunion", 0, []string{"re"}},
+		{"Réu", 0, []string{"re"}},
+		// substring
+		{"kingdom", 0, []string{"gb"}},
+		{"echi", 0, []string{"cz"}},
+		// substring shorter than trigram
+		{"hi", 0, []string{"cz"}},
+		{"K", 0, []string{"gb", "sk"}},
+		// fuzzy matches rank after prefix matches
+		{"slovakai", 0, []string{"sk", "si"}},
+		{"united", 1, []string{"us"}},
+		{"", 0, []string{}},
+		{"xyz", 0, []string{}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, searchIDs(idMapper.Search(test.query, test.limit)), test.query)
+	}
+
+	results := idMapper.Search("slovakia", 0)
+	assert.Equal(t, idmapper.NameScore, results[0].Score)
+	assert.Equal(t, "Slovakia", results[0].Name)
+
+	// index is rebuilt on reload
+	source.values = idmapper.ValuesMap{"sk": "Slovensko"}
+	assert.Nil(t, idMapper.Reload())
+	assert.Equal(t, []string{"sk"}, searchIDs(idMapper.Search("slovensko", 0)))
+	results = idMapper.Search("slovenia", 0)
+	assert.Equal(t, []string{"sk"}, searchIDs(results))
+	assert.True(t, results[0].Score < idmapper.SubstringScore)
+}
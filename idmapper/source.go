@@ -1,13 +1,35 @@
 package idmapper
 
+import "context"
+
 // SourceReader interface for reading values from source
 type SourceReader interface {
 	Read() (ValuesMap, error)
 }
 
+// ContextSourceReader is SourceReader which reading can be cancelled using context
+type ContextSourceReader interface {
+	SourceReader
+	ReadContext(ctx context.Context) (ValuesMap, error)
+}
+
 // SourceReaderFunc is adapter to allow use ordinary function as SourceReader
 type SourceReaderFunc func() (ValuesMap, error)
 
 func (fn SourceReaderFunc) Read() (ValuesMap, error) {
 	return fn()
 }
+
+// ReadContext reads values from source using context if source is ContextSourceReader.
+// Other sources are read without cancellation, but their values are discarded if context is done in meantime
+func ReadContext(ctx context.Context, source SourceReader) (ValuesMap, error) {
+	if contextSource, ok := source.(ContextSourceReader); ok {
+		return contextSource.ReadContext(ctx)
+	}
+
+	values, err := source.Read()
+	if err == nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return values, err
+}
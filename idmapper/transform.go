@@ -0,0 +1,125 @@
+package idmapper
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Transform transforms single value read from source. Returns new ID and name of value, and false if value should be dropped
+type Transform func(id, name string) (string, string, bool)
+
+// TransformSource creates SourceReader that applies transforms in given order to each value read from source.
+// Values are processed in order of their original IDs, if more values are transformed to the same ID, the last one is kept
+func TransformSource(source SourceReader, transforms ...Transform) SourceReader {
+	return &transformSource{
+		source:     source,
+		transforms: transforms,
+	}
+}
+
+type transformSource struct {
+	source     SourceReader
+	transforms []Transform
+}
+
+func (source *transformSource) Read() (ValuesMap, error) {
+	return source.ReadContext(context.Background())
+}
+
+func (source *transformSource) ReadContext(ctx context.Context) (ValuesMap, error) {
+	values, err := ReadContext(ctx, source.source)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformValues(values, source.transforms), nil
+}
+
+func transformValues(values ValuesMap, transforms []Transform) ValuesMap {
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	result := make(ValuesMap, len(values))
+
+	for _, id := range ids {
+		newID, name, keep := id, values[id], true
+		for _, transform := range transforms {
+			newID, name, keep = transform(newID, name)
+			if !keep {
+				break
+			}
+		}
+
+		if keep {
+			result[newID] = name
+		}
+	}
+
+	return result
+}
+
+// TrimSpace removes leading and trailing white space from ID and name
+func TrimSpace() Transform {
+	return func(id, name string) (string, string, bool) {
+		return strings.TrimSpace(id), strings.TrimSpace(name), true
+	}
+}
+
+// LowerID converts ID to lower case
+func LowerID() Transform {
+	return func(id, name string) (string, string, bool) {
+		return strings.ToLower(id), name, true
+	}
+}
+
+// UpperID converts ID to upper case
+func UpperID() Transform {
+	return func(id, name string) (string, string, bool) {
+		return strings.ToUpper(id), name, true
+	}
+}
+
+// NormalizeName converts name to Unicode Normalization Form C
+func NormalizeName() Transform {
+	return func(id, name string) (string, string, bool) {
+		return id, norm.NFC.String(name), true
+	}
+}
+
+// FilterID keeps only values with ID matching pattern
+func FilterID(pattern *regexp.Regexp) Transform {
+	return func(id, name string) (string, string, bool) {
+		return id, name, pattern.MatchString(id)
+	}
+}
+
+// RenameID replaces ID found in table by its new ID from table
+func RenameID(table map[string]string) Transform {
+	return func(id, name string) (string, string, bool) {
+		if newID, found := table[id]; found {
+			return newID, name, true
+		}
+		return id, name, true
+	}
+}
+
+// PrefixID adds prefix to ID
+func PrefixID(prefix string) Transform {
+	return func(id, name string) (string, string, bool) {
+		return prefix + id, name, true
+	}
+}
+
+// DropEmptyNames drops values with empty name
+func DropEmptyNames() Transform {
+	return func(id, name string) (string, string, bool) {
+		return id, name, name != ""
+	}
+}
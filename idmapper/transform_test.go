@@ -0,0 +1,89 @@
+package idmapper_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformSource(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{
+			" SK ": " Slovakia ",
+			"CZ":   "Czechia",
+			"YU":   "Yugoslavia",
+			"XX":   "",
+			"UK":   "United Kingdom",
+			"1":    "One",
+		},
+	}
+
+	idMapper, err := idmapper.NewIDMapper(idmapper.TransformSource(source,
+		idmapper.TrimSpace(),
+		idmapper.DropEmptyNames(),
+		idmapper.FilterID(regexp.MustCompile("^[A-Z]{2}$")),
+		idmapper.RenameID(map[string]string{"UK": "GB", "YU": ""}),
+		idmapper.FilterID(regexp.MustCompile(".+")),
+		idmapper.LowerID(),
+		idmapper.PrefixID("country:"),
+	))
+	assert.Nil(t, err)
+
+	expected := map[string]string{
+		"country:sk": "Slovakia",
+		"country:cz": "Czechia",
+		"country:gb": "United Kingdom",
+	}
+	for id, name := range expected {
+		result, found := idMapper.Get(id)
+		assert.True(t, found, id)
+		assert.Equal(t, name, result)
+	}
+
+	for _, id := range []string{"country:yu", "country:", "country:xx", "country:1", "country:uk", "SK"} {
+		_, found := idMapper.Get(id)
+		assert.False(t, found, id)
+	}
+}
+
+func TestTransformSourceError(t *testing.T) {
+	_, err := idmapper.NewIDMapper(idmapper.TransformSource(&TestingSourceInvalid{}, idmapper.LowerID()))
+	assert.EqualError(t, err, errReadFailedString)
+}
+
+func TestTransformSourceCollision(t *testing.T) {
+	source := &TestingSourceValid{
+		values: idmapper.ValuesMap{
+			"sk": "first",
+			"Sk": "second",
+			"SK": "third",
+		},
+	}
+
+	// values are processed in order of original IDs ("SK", "Sk", "sk"), last one is kept
+	idMapper, err := idmapper.NewIDMapper(idmapper.TransformSource(source, idmapper.UpperID()))
+	assert.Nil(t, err)
+
+	name, found := idMapper.Get("SK")
+	assert.True(t, found)
+	assert.Equal(t, "first", name)
+}
+
+func TestNormalizeName(t *testing.T) {
+	transform := idmapper.NormalizeName()
+
+	// "A" followed by combining ring above is composed to single "\u00c5"
+	id, name, keep := transform("ax", "A\u030aland Islands")
+	assert.True(t, keep)
+	assert.Equal(t, "ax", id)
+	assert.Equal(t, "\u00c5land Islands", name)
+}
+
+func TestUpperID(t *testing.T) {
+	id, name, keep := idmapper.UpperID()("eur", "Euro")
+	assert.True(t, keep)
+	assert.Equal(t, "EUR", id)
+	assert.Equal(t, "Euro", name)
+}
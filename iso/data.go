@@ -0,0 +1,932 @@
+// Code generated by isogen. DO NOT EDIT.
+
+package iso
+
+// countries is snapshot of ISO 3166-1 (249 entries)
+var countries = []Entry{
+	{Alpha2: "AW", Alpha3: "ABW", Numeric: "533", Name: "Aruba"},
+	{Alpha2: "AF", Alpha3: "AFG", Numeric: "004", Name: "Afghanistan"},
+	{Alpha2: "AO", Alpha3: "AGO", Numeric: "024", Name: "Angola"},
+	{Alpha2: "AI", Alpha3: "AIA", Numeric: "660", Name: "Anguilla"},
+	{Alpha2: "AX", Alpha3: "ALA", Numeric: "248", Name: "Åland Islands"},
+	{Alpha2: "AL", Alpha3: "ALB", Numeric: "008", Name: "Albania"},
+	{Alpha2: "AD", Alpha3: "AND", Numeric: "020", Name: "Andorra"},
+	{Alpha2: "AE", Alpha3: "ARE", Numeric: "784", Name: "United Arab Emirates"},
+	{Alpha2: "AR", Alpha3: "ARG", Numeric: "032", Name: "Argentina"},
+	{Alpha2: "AM", Alpha3: "ARM", Numeric: "051", Name: "Armenia"},
+	{Alpha2: "AS", Alpha3: "ASM", Numeric: "016", Name: "American Samoa"},
+	{Alpha2: "AQ", Alpha3: "ATA", Numeric: "010", Name: "Antarctica"},
+	{Alpha2: "TF", Alpha3: "ATF", Numeric: "260", Name: "French Southern Territories"},
+	{Alpha2: "AG", Alpha3: "ATG", Numeric: "028", Name: "Antigua and Barbuda"},
+	{Alpha2: "AU", Alpha3: "AUS", Numeric: "036", Name: "Australia"},
+	{Alpha2: "AT", Alpha3: "AUT", Numeric: "040", Name: "Austria"},
+	{Alpha2: "AZ", Alpha3: "AZE", Numeric: "031", Name: "Azerbaijan"},
+	{Alpha2: "BI", Alpha3: "BDI", Numeric: "108", Name: "Burundi"},
+	{Alpha2: "BE", Alpha3: "BEL", Numeric: "056", Name: "Belgium"},
+	{Alpha2: "BJ", Alpha3: "BEN", Numeric: "204", Name: "Benin"},
+	{Alpha2: "BQ", Alpha3: "BES", Numeric: "535", Name: "Bonaire, Sint Eustatius and Saba"},
+	{Alpha2: "BF", Alpha3: "BFA", Numeric: "854", Name: "Burkina Faso"},
+	{Alpha2: "BD", Alpha3: "BGD", Numeric: "050", Name: "Bangladesh"},
+	{Alpha2: "BG", Alpha3: "BGR", Numeric: "100", Name: "Bulgaria"},
+	{Alpha2: "BH", Alpha3: "BHR", Numeric: "048", Name: "Bahrain"},
+	{Alpha2: "BS", Alpha3: "BHS", Numeric: "044", Name: "Bahamas"},
+	{Alpha2: "BA", Alpha3: "BIH", Numeric: "070", Name: "Bosnia and Herzegovina"},
+	{Alpha2: "BL", Alpha3: "BLM", Numeric: "652", Name: "Saint Barthélemy"},
+	{Alpha2: "BY", Alpha3: "BLR", Numeric: "112", Name: "Belarus"},
+	{Alpha2: "BZ", Alpha3: "BLZ", Numeric: "084", Name: "Belize"},
+	{Alpha2: "BM", Alpha3: "BMU", Numeric: "060", Name: "Bermuda"},
+	{Alpha2: "BO", Alpha3: "BOL", Numeric: "068", Name: "Bolivia, Plurinational State of"},
+	{Alpha2: "BR", Alpha3: "BRA", Numeric: "076", Name: "Brazil"},
+	{Alpha2: "BB", Alpha3: "BRB", Numeric: "052", Name: "Barbados"},
+	{Alpha2: "BN", Alpha3: "BRN", Numeric: "096", Name: "Brunei Darussalam"},
+	{Alpha2: "BT", Alpha3: "BTN", Numeric: "064", Name: "Bhutan"},
+	{Alpha2: "BV", Alpha3: "BVT", Numeric: "074", Name: "Bouvet Island"},
+	{Alpha2: "BW", Alpha3: "BWA", Numeric: "072", Name: "Botswana"},
+	{Alpha2: "CF", Alpha3: "CAF", Numeric: "140", Name: "Central African Republic"},
+	{Alpha2: "CA", Alpha3: "CAN", Numeric: "124", Name: "Canada"},
+	{Alpha2: "CC", Alpha3: "CCK", Numeric: "166", Name: "Cocos (Keeling) Islands"},
+	{Alpha2: "CH", Alpha3: "CHE", Numeric: "756", Name: "Switzerland"},
+	{Alpha2: "CL", Alpha3: "CHL", Numeric: "152", Name: "Chile"},
+	{Alpha2: "CN", Alpha3: "CHN", Numeric: "156", Name: "China"},
+	{Alpha2: "CI", Alpha3: "CIV", Numeric: "384", Name: "Côte d'Ivoire"},
+	{Alpha2: "CM", Alpha3: "CMR", Numeric: "120", Name: "Cameroon"},
+	{Alpha2: "CD", Alpha3: "COD", Numeric: "180", Name: "Congo, The Democratic Republic of the"},
+	{Alpha2: "CG", Alpha3: "COG", Numeric: "178", Name: "Congo"},
+	{Alpha2: "CK", Alpha3: "COK", Numeric: "184", Name: "Cook Islands"},
+	{Alpha2: "CO", Alpha3: "COL", Numeric: "170", Name: "Colombia"},
+	{Alpha2: "KM", Alpha3: "COM", Numeric: "174", Name: "Comoros"},
+	{Alpha2: "CV", Alpha3: "CPV", Numeric: "132", Name: "Cabo Verde"},
+	{Alpha2: "CR", Alpha3: "CRI", Numeric: "188", Name: "Costa Rica"},
+	{Alpha2: "CU", Alpha3: "CUB", Numeric: "192", Name: "Cuba"},
+	{Alpha2: "CW", Alpha3: "CUW", Numeric: "531", Name: "Curaçao"},
+	{Alpha2: "CX", Alpha3: "CXR", Numeric: "162", Name: "Christmas Island"},
+	{Alpha2: "KY", Alpha3: "CYM", Numeric: "136", Name: "Cayman Islands"},
+	{Alpha2: "CY", Alpha3: "CYP", Numeric: "196", Name: "Cyprus"},
+	{Alpha2: "CZ", Alpha3: "CZE", Numeric: "203", Name: "Czechia"},
+	{Alpha2: "DE", Alpha3: "DEU", Numeric: "276", Name: "Germany"},
+	{Alpha2: "DJ", Alpha3: "DJI", Numeric: "262", Name: "Djibouti"},
+	{Alpha2: "DM", Alpha3: "DMA", Numeric: "212", Name: "Dominica"},
+	{Alpha2: "DK", Alpha3: "DNK", Numeric: "208", Name: "Denmark"},
+	{Alpha2: "DO", Alpha3: "DOM", Numeric: "214", Name: "Dominican Republic"},
+	{Alpha2: "DZ", Alpha3: "DZA", Numeric: "012", Name: "Algeria"},
+	{Alpha2: "EC", Alpha3: "ECU", Numeric: "218", Name: "Ecuador"},
+	{Alpha2: "EG", Alpha3: "EGY", Numeric: "818", Name: "Egypt"},
+	{Alpha2: "ER", Alpha3: "ERI", Numeric: "232", Name: "Eritrea"},
+	{Alpha2: "EH", Alpha3: "ESH", Numeric: "732", Name: "Western Sahara"},
+	{Alpha2: "ES", Alpha3: "ESP", Numeric: "724", Name: "Spain"},
+	{Alpha2: "EE", Alpha3: "EST", Numeric: "233", Name: "Estonia"},
+	{Alpha2: "ET", Alpha3: "ETH", Numeric: "231", Name: "Ethiopia"},
+	{Alpha2: "FI", Alpha3: "FIN", Numeric: "246", Name: "Finland"},
+	{Alpha2: "FJ", Alpha3: "FJI", Numeric: "242", Name: "Fiji"},
+	{Alpha2: "FK", Alpha3: "FLK", Numeric: "238", Name: "Falkland Islands (Malvinas)"},
+	{Alpha2: "FR", Alpha3: "FRA", Numeric: "250", Name: "France"},
+	{Alpha2: "FO", Alpha3: "FRO", Numeric: "234", Name: "Faroe Islands"},
+	{Alpha2: "FM", Alpha3: "FSM", Numeric: "583", Name: "Micronesia, Federated States of"},
+	{Alpha2: "GA", Alpha3: "GAB", Numeric: "266", Name: "Gabon"},
+	{Alpha2: "GB", Alpha3: "GBR", Numeric: "826", Name: "United Kingdom"},
+	{Alpha2: "GE", Alpha3: "GEO", Numeric: "268", Name: "Georgia"},
+	{Alpha2: "GG", Alpha3: "GGY", Numeric: "831", Name: "Guernsey"},
+	{Alpha2: "GH", Alpha3: "GHA", Numeric: "288", Name: "Ghana"},
+	{Alpha2: "GI", Alpha3: "GIB", Numeric: "292", Name: "Gibraltar"},
+	{Alpha2: "GN", Alpha3: "GIN", Numeric: "324", Name: "Guinea"},
+	{Alpha2: "GP", Alpha3: "GLP", Numeric: "312", Name: "Guadeloupe"},
+	{Alpha2: "GM", Alpha3: "GMB", Numeric: "270", Name: "Gambia"},
+	{Alpha2: "GW", Alpha3: "GNB", Numeric: "624", Name: "Guinea-Bissau"},
+	{Alpha2: "GQ", Alpha3: "GNQ", Numeric: "226", Name: "Equatorial Guinea"},
+	{Alpha2: "GR", Alpha3: "GRC", Numeric: "300", Name: "Greece"},
+	{Alpha2: "GD", Alpha3: "GRD", Numeric: "308", Name: "Grenada"},
+	{Alpha2: "GL", Alpha3: "GRL", Numeric: "304", Name: "Greenland"},
+	{Alpha2: "GT", Alpha3: "GTM", Numeric: "320", Name: "Guatemala"},
+	{Alpha2: "GF", Alpha3: "GUF", Numeric: "254", Name: "French Guiana"},
+	{Alpha2: "GU", Alpha3: "GUM", Numeric: "316", Name: "Guam"},
+	{Alpha2: "GY", Alpha3: "GUY", Numeric: "328", Name: "Guyana"},
+	{Alpha2: "HK", Alpha3: "HKG", Numeric: "344", Name: "Hong Kong"},
+	{Alpha2: "HM", Alpha3: "HMD", Numeric: "334", Name: "Heard Island and McDonald Islands"},
+	{Alpha2: "HN", Alpha3: "HND", Numeric: "340", Name: "Honduras"},
+	{Alpha2: "HR", Alpha3: "HRV", Numeric: "191", Name: "Croatia"},
+	{Alpha2: "HT", Alpha3: "HTI", Numeric: "332", Name: "Haiti"},
+	{Alpha2: "HU", Alpha3: "HUN", Numeric: "348", Name: "Hungary"},
+	{Alpha2: "ID", Alpha3: "IDN", Numeric: "360", Name: "Indonesia"},
+	{Alpha2: "IM", Alpha3: "IMN", Numeric: "833", Name: "Isle of Man"},
+	{Alpha2: "IN", Alpha3: "IND", Numeric: "356", Name: "India"},
+	{Alpha2: "IO", Alpha3: "IOT", Numeric: "086", Name: "British Indian Ocean Territory"},
+	{Alpha2: "IE", Alpha3: "IRL", Numeric: "372", Name: "Ireland"},
+	{Alpha2: "IR", Alpha3: "IRN", Numeric: "364", Name: "Iran, Islamic Republic of"},
+	{Alpha2: "IQ", Alpha3: "IRQ", Numeric: "368", Name: "Iraq"},
+	{Alpha2: "IS", Alpha3: "ISL", Numeric: "352", Name: "Iceland"},
+	{Alpha2: "IL", Alpha3: "ISR", Numeric: "376", Name: "Israel"},
+	{Alpha2: "IT", Alpha3: "ITA", Numeric: "380", Name: "Italy"},
+	{Alpha2: "JM", Alpha3: "JAM", Numeric: "388", Name: "Jamaica"},
+	{Alpha2: "JE", Alpha3: "JEY", Numeric: "832", Name: "Jersey"},
+	{Alpha2: "JO", Alpha3: "JOR", Numeric: "400", Name: "Jordan"},
+	{Alpha2: "JP", Alpha3: "JPN", Numeric: "392", Name: "Japan"},
+	{Alpha2: "KZ", Alpha3: "KAZ", Numeric: "398", Name: "Kazakhstan"},
+	{Alpha2: "KE", Alpha3: "KEN", Numeric: "404", Name: "Kenya"},
+	{Alpha2: "KG", Alpha3: "KGZ", Numeric: "417", Name: "Kyrgyzstan"},
+	{Alpha2: "KH", Alpha3: "KHM", Numeric: "116", Name: "Cambodia"},
+	{Alpha2: "KI", Alpha3: "KIR", Numeric: "296", Name: "Kiribati"},
+	{Alpha2: "KN", Alpha3: "KNA", Numeric: "659", Name: "Saint Kitts and Nevis"},
+	{Alpha2: "KR", Alpha3: "KOR", Numeric: "410", Name: "Korea, Republic of"},
+	{Alpha2: "KW", Alpha3: "KWT", Numeric: "414", Name: "Kuwait"},
+	{Alpha2: "LA", Alpha3: "LAO", Numeric: "418", Name: "Lao People's Democratic Republic"},
+	{Alpha2: "LB", Alpha3: "LBN", Numeric: "422", Name: "Lebanon"},
+	{Alpha2: "LR", Alpha3: "LBR", Numeric: "430", Name: "Liberia"},
+	{Alpha2: "LY", Alpha3: "LBY", Numeric: "434", Name: "Libya"},
+	{Alpha2: "LC", Alpha3: "LCA", Numeric: "662", Name: "Saint Lucia"},
+	{Alpha2: "LI", Alpha3: "LIE", Numeric: "438", Name: "Liechtenstein"},
+	{Alpha2: "LK", Alpha3: "LKA", Numeric: "144", Name: "Sri Lanka"},
+	{Alpha2: "LS", Alpha3: "LSO", Numeric: "426", Name: "Lesotho"},
+	{Alpha2: "LT", Alpha3: "LTU", Numeric: "440", Name: "Lithuania"},
+	{Alpha2: "LU", Alpha3: "LUX", Numeric: "442", Name: "Luxembourg"},
+	{Alpha2: "LV", Alpha3: "LVA", Numeric: "428", Name: "Latvia"},
+	{Alpha2: "MO", Alpha3: "MAC", Numeric: "446", Name: "Macao"},
+	{Alpha2: "MF", Alpha3: "MAF", Numeric: "663", Name: "Saint Martin (French part)"},
+	{Alpha2: "MA", Alpha3: "MAR", Numeric: "504", Name: "Morocco"},
+	{Alpha2: "MC", Alpha3: "MCO", Numeric: "492", Name: "Monaco"},
+	{Alpha2: "MD", Alpha3: "MDA", Numeric: "498", Name: "Moldova, Republic of"},
+	{Alpha2: "MG", Alpha3: "MDG", Numeric: "450", Name: "Madagascar"},
+	{Alpha2: "MV", Alpha3: "MDV", Numeric: "462", Name: "Maldives"},
+	{Alpha2: "MX", Alpha3: "MEX", Numeric: "484", Name: "Mexico"},
+	{Alpha2: "MH", Alpha3: "MHL", Numeric: "584", Name: "Marshall Islands"},
+	{Alpha2: "MK", Alpha3: "MKD", Numeric: "807", Name: "North Macedonia"},
+	{Alpha2: "ML", Alpha3: "MLI", Numeric: "466", Name: "Mali"},
+	{Alpha2: "MT", Alpha3: "MLT", Numeric: "470", Name: "Malta"},
+	{Alpha2: "MM", Alpha3: "MMR", Numeric: "104", Name: "Myanmar"},
+	{Alpha2: "ME", Alpha3: "MNE", Numeric: "499", Name: "Montenegro"},
+	{Alpha2: "MN", Alpha3: "MNG", Numeric: "496", Name: "Mongolia"},
+	{Alpha2: "MP", Alpha3: "MNP", Numeric: "580", Name: "Northern Mariana Islands"},
+	{Alpha2: "MZ", Alpha3: "MOZ", Numeric: "508", Name: "Mozambique"},
+	{Alpha2: "MR", Alpha3: "MRT", Numeric: "478", Name: "Mauritania"},
+	{Alpha2: "MS", Alpha3: "MSR", Numeric: "500", Name: "Montserrat"},
+	{Alpha2: "MQ", Alpha3: "MTQ", Numeric: "474", Name: "Martinique"},
+	{Alpha2: "MU", Alpha3: "MUS", Numeric: "480", Name: "Mauritius"},
+	{Alpha2: "MW", Alpha3: "MWI", Numeric: "454", Name: "Malawi"},
+	{Alpha2: "MY", Alpha3: "MYS", Numeric: "458", Name: "Malaysia"},
+	{Alpha2: "YT", Alpha3: "MYT", Numeric: "175", Name: "Mayotte"},
+	{Alpha2: "NA", Alpha3: "NAM", Numeric: "516", Name: "Namibia"},
+	{Alpha2: "NC", Alpha3: "NCL", Numeric: "540", Name: "New Caledonia"},
+	{Alpha2: "NE", Alpha3: "NER", Numeric: "562", Name: "Niger"},
+	{Alpha2: "NF", Alpha3: "NFK", Numeric: "574", Name: "Norfolk Island"},
+	{Alpha2: "NG", Alpha3: "NGA", Numeric: "566", Name: "Nigeria"},
+	{Alpha2: "NI", Alpha3: "NIC", Numeric: "558", Name: "Nicaragua"},
+	{Alpha2: "NU", Alpha3: "NIU", Numeric: "570", Name: "Niue"},
+	{Alpha2: "NL", Alpha3: "NLD", Numeric: "528", Name: "Netherlands"},
+	{Alpha2: "NO", Alpha3: "NOR", Numeric: "578", Name: "Norway"},
+	{Alpha2: "NP", Alpha3: "NPL", Numeric: "524", Name: "Nepal"},
+	{Alpha2: "NR", Alpha3: "NRU", Numeric: "520", Name: "Nauru"},
+	{Alpha2: "NZ", Alpha3: "NZL", Numeric: "554", Name: "New Zealand"},
+	{Alpha2: "OM", Alpha3: "OMN", Numeric: "512", Name: "Oman"},
+	{Alpha2: "PK", Alpha3: "PAK", Numeric: "586", Name: "Pakistan"},
+	{Alpha2: "PA", Alpha3: "PAN", Numeric: "591", Name: "Panama"},
+	{Alpha2: "PN", Alpha3: "PCN", Numeric: "612", Name: "Pitcairn"},
+	{Alpha2: "PE", Alpha3: "PER", Numeric: "604", Name: "Peru"},
+	{Alpha2: "PH", Alpha3: "PHL", Numeric: "608", Name: "Philippines"},
+	{Alpha2: "PW", Alpha3: "PLW", Numeric: "585", Name: "Palau"},
+	{Alpha2: "PG", Alpha3: "PNG", Numeric: "598", Name: "Papua New Guinea"},
+	{Alpha2: "PL", Alpha3: "POL", Numeric: "616", Name: "Poland"},
+	{Alpha2: "PR", Alpha3: "PRI", Numeric: "630", Name: "Puerto Rico"},
+	{Alpha2: "KP", Alpha3: "PRK", Numeric: "408", Name: "Korea, Democratic People's Republic of"},
+	{Alpha2: "PT", Alpha3: "PRT", Numeric: "620", Name: "Portugal"},
+	{Alpha2: "PY", Alpha3: "PRY", Numeric: "600", Name: "Paraguay"},
+	{Alpha2: "PS", Alpha3: "PSE", Numeric: "275", Name: "Palestine, State of"},
+	{Alpha2: "PF", Alpha3: "PYF", Numeric: "258", Name: "French Polynesia"},
+	{Alpha2: "QA", Alpha3: "QAT", Numeric: "634", Name: "Qatar"},
+	{Alpha2: "RE", Alpha3: "REU", Numeric: "638", Name: "Réunion"},
+	{Alpha2: "RO", Alpha3: "ROU", Numeric: "642", Name: "Romania"},
+	{Alpha2: "RU", Alpha3: "RUS", Numeric: "643", Name: "Russian Federation"},
+	{Alpha2: "RW", Alpha3: "RWA", Numeric: "646", Name: "Rwanda"},
+	{Alpha2: "SA", Alpha3: "SAU", Numeric: "682", Name: "Saudi Arabia"},
+	{Alpha2: "SD", Alpha3: "SDN", Numeric: "729", Name: "Sudan"},
+	{Alpha2: "SN", Alpha3: "SEN", Numeric: "686", Name: "Senegal"},
+	{Alpha2: "SG", Alpha3: "SGP", Numeric: "702", Name: "Singapore"},
+	{Alpha2: "GS", Alpha3: "SGS", Numeric: "239", Name: "South Georgia and the South Sandwich Islands"},
+	{Alpha2: "SH", Alpha3: "SHN", Numeric: "654", Name: "Saint Helena, Ascension and Tristan da Cunha"},
+	{Alpha2: "SJ", Alpha3: "SJM", Numeric: "744", Name: "Svalbard and Jan Mayen"},
+	{Alpha2: "SB", Alpha3: "SLB", Numeric: "090", Name: "Solomon Islands"},
+	{Alpha2: "SL", Alpha3: "SLE", Numeric: "694", Name: "Sierra Leone"},
+	{Alpha2: "SV", Alpha3: "SLV", Numeric: "222", Name: "El Salvador"},
+	{Alpha2: "SM", Alpha3: "SMR", Numeric: "674", Name: "San Marino"},
+	{Alpha2: "SO", Alpha3: "SOM", Numeric: "706", Name: "Somalia"},
+	{Alpha2: "PM", Alpha3: "SPM", Numeric: "666", Name: "Saint Pierre and Miquelon"},
+	{Alpha2: "RS", Alpha3: "SRB", Numeric: "688", Name: "Serbia"},
+	{Alpha2: "SS", Alpha3: "SSD", Numeric: "728", Name: "South Sudan"},
+	{Alpha2: "ST", Alpha3: "STP", Numeric: "678", Name: "Sao Tome and Principe"},
+	{Alpha2: "SR", Alpha3: "SUR", Numeric: "740", Name: "Suriname"},
+	{Alpha2: "SK", Alpha3: "SVK", Numeric: "703", Name: "Slovakia"},
+	{Alpha2: "SI", Alpha3: "SVN", Numeric: "705", Name: "Slovenia"},
+	{Alpha2: "SE", Alpha3: "SWE", Numeric: "752", Name: "Sweden"},
+	{Alpha2: "SZ", Alpha3: "SWZ", Numeric: "748", Name: "Eswatini"},
+	{Alpha2: "SX", Alpha3: "SXM", Numeric: "534", Name: "Sint Maarten (Dutch part)"},
+	{Alpha2: "SC", Alpha3: "SYC", Numeric: "690", Name: "Seychelles"},
+	{Alpha2: "SY", Alpha3: "SYR", Numeric: "760", Name: "Syrian Arab Republic"},
+	{Alpha2: "TC", Alpha3: "TCA", Numeric: "796", Name: "Turks and Caicos Islands"},
+	{Alpha2: "TD", Alpha3: "TCD", Numeric: "148", Name: "Chad"},
+	{Alpha2: "TG", Alpha3: "TGO", Numeric: "768", Name: "Togo"},
+	{Alpha2: "TH", Alpha3: "THA", Numeric: "764", Name: "Thailand"},
+	{Alpha2: "TJ", Alpha3: "TJK", Numeric: "762", Name: "Tajikistan"},
+	{Alpha2: "TK", Alpha3: "TKL", Numeric: "772", Name: "Tokelau"},
+	{Alpha2: "TM", Alpha3: "TKM", Numeric: "795", Name: "Turkmenistan"},
+	{Alpha2: "TL", Alpha3: "TLS", Numeric: "626", Name: "Timor-Leste"},
+	{Alpha2: "TO", Alpha3: "TON", Numeric: "776", Name: "Tonga"},
+	{Alpha2: "TT", Alpha3: "TTO", Numeric: "780", Name: "Trinidad and Tobago"},
+	{Alpha2: "TN", Alpha3: "TUN", Numeric: "788", Name: "Tunisia"},
+	{Alpha2: "TR", Alpha3: "TUR", Numeric: "792", Name: "Türkiye"},
+	{Alpha2: "TV", Alpha3: "TUV", Numeric: "798", Name: "Tuvalu"},
+	{Alpha2: "TW", Alpha3: "TWN", Numeric: "158", Name: "Taiwan, Province of China"},
+	{Alpha2: "TZ", Alpha3: "TZA", Numeric: "834", Name: "Tanzania, United Republic of"},
+	{Alpha2: "UG", Alpha3: "UGA", Numeric: "800", Name: "Uganda"},
+	{Alpha2: "UA", Alpha3: "UKR", Numeric: "804", Name: "Ukraine"},
+	{Alpha2: "UM", Alpha3: "UMI", Numeric: "581", Name: "United States Minor Outlying Islands"},
+	{Alpha2: "UY", Alpha3: "URY", Numeric: "858", Name: "Uruguay"},
+	{Alpha2: "US", Alpha3: "USA", Numeric: "840", Name: "United States"},
+	{Alpha2: "UZ", Alpha3: "UZB", Numeric: "860", Name: "Uzbekistan"},
+	{Alpha2: "VA", Alpha3: "VAT", Numeric: "336", Name: "Holy See (Vatican City State)"},
+	{Alpha2: "VC", Alpha3: "VCT", Numeric: "670", Name: "Saint Vincent and the Grenadines"},
+	{Alpha2: "VE", Alpha3: "VEN", Numeric: "862", Name: "Venezuela, Bolivarian Republic of"},
+	{Alpha2: "VG", Alpha3: "VGB", Numeric: "092", Name: "Virgin Islands, British"},
+	{Alpha2: "VI", Alpha3: "VIR", Numeric: "850", Name: "Virgin Islands, U.S."},
+	{Alpha2: "VN", Alpha3: "VNM", Numeric: "704", Name: "Viet Nam"},
+	{Alpha2: "VU", Alpha3: "VUT", Numeric: "548", Name: "Vanuatu"},
+	{Alpha2: "WF", Alpha3: "WLF", Numeric: "876", Name: "Wallis and Futuna"},
+	{Alpha2: "WS", Alpha3: "WSM", Numeric: "882", Name: "Samoa"},
+	{Alpha2: "YE", Alpha3: "YEM", Numeric: "887", Name: "Yemen"},
+	{Alpha2: "ZA", Alpha3: "ZAF", Numeric: "710", Name: "South Africa"},
+	{Alpha2: "ZM", Alpha3: "ZMB", Numeric: "894", Name: "Zambia"},
+	{Alpha2: "ZW", Alpha3: "ZWE", Numeric: "716", Name: "Zimbabwe"},
+}
+
+// currencies is snapshot of ISO 4217 (181 entries)
+var currencies = []Entry{
+	{Alpha2: "", Alpha3: "AED", Numeric: "784", Name: "UAE Dirham"},
+	{Alpha2: "", Alpha3: "AFN", Numeric: "971", Name: "Afghani"},
+	{Alpha2: "", Alpha3: "ALL", Numeric: "008", Name: "Lek"},
+	{Alpha2: "", Alpha3: "AMD", Numeric: "051", Name: "Armenian Dram"},
+	{Alpha2: "", Alpha3: "ANG", Numeric: "532", Name: "Netherlands Antillean Guilder"},
+	{Alpha2: "", Alpha3: "AOA", Numeric: "973", Name: "Kwanza"},
+	{Alpha2: "", Alpha3: "ARS", Numeric: "032", Name: "Argentine Peso"},
+	{Alpha2: "", Alpha3: "AUD", Numeric: "036", Name: "Australian Dollar"},
+	{Alpha2: "", Alpha3: "AWG", Numeric: "533", Name: "Aruban Florin"},
+	{Alpha2: "", Alpha3: "AZN", Numeric: "944", Name: "Azerbaijan Manat"},
+	{Alpha2: "", Alpha3: "BAM", Numeric: "977", Name: "Convertible Mark"},
+	{Alpha2: "", Alpha3: "BBD", Numeric: "052", Name: "Barbados Dollar"},
+	{Alpha2: "", Alpha3: "BDT", Numeric: "050", Name: "Taka"},
+	{Alpha2: "", Alpha3: "BGN", Numeric: "975", Name: "Bulgarian Lev"},
+	{Alpha2: "", Alpha3: "BHD", Numeric: "048", Name: "Bahraini Dinar"},
+	{Alpha2: "", Alpha3: "BIF", Numeric: "108", Name: "Burundi Franc"},
+	{Alpha2: "", Alpha3: "BMD", Numeric: "060", Name: "Bermudian Dollar"},
+	{Alpha2: "", Alpha3: "BND", Numeric: "096", Name: "Brunei Dollar"},
+	{Alpha2: "", Alpha3: "BOB", Numeric: "068", Name: "Boliviano"},
+	{Alpha2: "", Alpha3: "BOV", Numeric: "984", Name: "Mvdol"},
+	{Alpha2: "", Alpha3: "BRL", Numeric: "986", Name: "Brazilian Real"},
+	{Alpha2: "", Alpha3: "BSD", Numeric: "044", Name: "Bahamian Dollar"},
+	{Alpha2: "", Alpha3: "BTN", Numeric: "064", Name: "Ngultrum"},
+	{Alpha2: "", Alpha3: "BWP", Numeric: "072", Name: "Pula"},
+	{Alpha2: "", Alpha3: "BYN", Numeric: "933", Name: "Belarusian Ruble"},
+	{Alpha2: "", Alpha3: "BZD", Numeric: "084", Name: "Belize Dollar"},
+	{Alpha2: "", Alpha3: "CAD", Numeric: "124", Name: "Canadian Dollar"},
+	{Alpha2: "", Alpha3: "CDF", Numeric: "976", Name: "Congolese Franc"},
+	{Alpha2: "", Alpha3: "CHE", Numeric: "947", Name: "WIR Euro"},
+	{Alpha2: "", Alpha3: "CHF", Numeric: "756", Name: "Swiss Franc"},
+	{Alpha2: "", Alpha3: "CHW", Numeric: "948", Name: "WIR Franc"},
+	{Alpha2: "", Alpha3: "CLF", Numeric: "990", Name: "Unidad de Fomento"},
+	{Alpha2: "", Alpha3: "CLP", Numeric: "152", Name: "Chilean Peso"},
+	{Alpha2: "", Alpha3: "CNY", Numeric: "156", Name: "Yuan Renminbi"},
+	{Alpha2: "", Alpha3: "COP", Numeric: "170", Name: "Colombian Peso"},
+	{Alpha2: "", Alpha3: "COU", Numeric: "970", Name: "Unidad de Valor Real"},
+	{Alpha2: "", Alpha3: "CRC", Numeric: "188", Name: "Costa Rican Colon"},
+	{Alpha2: "", Alpha3: "CUC", Numeric: "931", Name: "Peso Convertible"},
+	{Alpha2: "", Alpha3: "CUP", Numeric: "192", Name: "Cuban Peso"},
+	{Alpha2: "", Alpha3: "CVE", Numeric: "132", Name: "Cabo Verde Escudo"},
+	{Alpha2: "", Alpha3: "CZK", Numeric: "203", Name: "Czech Koruna"},
+	{Alpha2: "", Alpha3: "DJF", Numeric: "262", Name: "Djibouti Franc"},
+	{Alpha2: "", Alpha3: "DKK", Numeric: "208", Name: "Danish Krone"},
+	{Alpha2: "", Alpha3: "DOP", Numeric: "214", Name: "Dominican Peso"},
+	{Alpha2: "", Alpha3: "DZD", Numeric: "012", Name: "Algerian Dinar"},
+	{Alpha2: "", Alpha3: "EGP", Numeric: "818", Name: "Egyptian Pound"},
+	{Alpha2: "", Alpha3: "ERN", Numeric: "232", Name: "Nakfa"},
+	{Alpha2: "", Alpha3: "ETB", Numeric: "230", Name: "Ethiopian Birr"},
+	{Alpha2: "", Alpha3: "EUR", Numeric: "978", Name: "Euro"},
+	{Alpha2: "", Alpha3: "FJD", Numeric: "242", Name: "Fiji Dollar"},
+	{Alpha2: "", Alpha3: "FKP", Numeric: "238", Name: "Falkland Islands Pound"},
+	{Alpha2: "", Alpha3: "GBP", Numeric: "826", Name: "Pound Sterling"},
+	{Alpha2: "", Alpha3: "GEL", Numeric: "981", Name: "Lari"},
+	{Alpha2: "", Alpha3: "GHS", Numeric: "936", Name: "Ghana Cedi"},
+	{Alpha2: "", Alpha3: "GIP", Numeric: "292", Name: "Gibraltar Pound"},
+	{Alpha2: "", Alpha3: "GMD", Numeric: "270", Name: "Dalasi"},
+	{Alpha2: "", Alpha3: "GNF", Numeric: "324", Name: "Guinean Franc"},
+	{Alpha2: "", Alpha3: "GTQ", Numeric: "320", Name: "Quetzal"},
+	{Alpha2: "", Alpha3: "GYD", Numeric: "328", Name: "Guyana Dollar"},
+	{Alpha2: "", Alpha3: "HKD", Numeric: "344", Name: "Hong Kong Dollar"},
+	{Alpha2: "", Alpha3: "HNL", Numeric: "340", Name: "Lempira"},
+	{Alpha2: "", Alpha3: "HRK", Numeric: "191", Name: "Kuna"},
+	{Alpha2: "", Alpha3: "HTG", Numeric: "332", Name: "Gourde"},
+	{Alpha2: "", Alpha3: "HUF", Numeric: "348", Name: "Forint"},
+	{Alpha2: "", Alpha3: "IDR", Numeric: "360", Name: "Rupiah"},
+	{Alpha2: "", Alpha3: "ILS", Numeric: "376", Name: "New Israeli Sheqel"},
+	{Alpha2: "", Alpha3: "INR", Numeric: "356", Name: "Indian Rupee"},
+	{Alpha2: "", Alpha3: "IQD", Numeric: "368", Name: "Iraqi Dinar"},
+	{Alpha2: "", Alpha3: "IRR", Numeric: "364", Name: "Iranian Rial"},
+	{Alpha2: "", Alpha3: "ISK", Numeric: "352", Name: "Iceland Krona"},
+	{Alpha2: "", Alpha3: "JMD", Numeric: "388", Name: "Jamaican Dollar"},
+	{Alpha2: "", Alpha3: "JOD", Numeric: "400", Name: "Jordanian Dinar"},
+	{Alpha2: "", Alpha3: "JPY", Numeric: "392", Name: "Yen"},
+	{Alpha2: "", Alpha3: "KES", Numeric: "404", Name: "Kenyan Shilling"},
+	{Alpha2: "", Alpha3: "KGS", Numeric: "417", Name: "Som"},
+	{Alpha2: "", Alpha3: "KHR", Numeric: "116", Name: "Riel"},
+	{Alpha2: "", Alpha3: "KMF", Numeric: "174", Name: "Comorian Franc"},
+	{Alpha2: "", Alpha3: "KPW", Numeric: "408", Name: "North Korean Won"},
+	{Alpha2: "", Alpha3: "KRW", Numeric: "410", Name: "Won"},
+	{Alpha2: "", Alpha3: "KWD", Numeric: "414", Name: "Kuwaiti Dinar"},
+	{Alpha2: "", Alpha3: "KYD", Numeric: "136", Name: "Cayman Islands Dollar"},
+	{Alpha2: "", Alpha3: "KZT", Numeric: "398", Name: "Tenge"},
+	{Alpha2: "", Alpha3: "LAK", Numeric: "418", Name: "Lao Kip"},
+	{Alpha2: "", Alpha3: "LBP", Numeric: "422", Name: "Lebanese Pound"},
+	{Alpha2: "", Alpha3: "LKR", Numeric: "144", Name: "Sri Lanka Rupee"},
+	{Alpha2: "", Alpha3: "LRD", Numeric: "430", Name: "Liberian Dollar"},
+	{Alpha2: "", Alpha3: "LSL", Numeric: "426", Name: "Loti"},
+	{Alpha2: "", Alpha3: "LYD", Numeric: "434", Name: "Libyan Dinar"},
+	{Alpha2: "", Alpha3: "MAD", Numeric: "504", Name: "Moroccan Dirham"},
+	{Alpha2: "", Alpha3: "MDL", Numeric: "498", Name: "Moldovan Leu"},
+	{Alpha2: "", Alpha3: "MGA", Numeric: "969", Name: "Malagasy Ariary"},
+	{Alpha2: "", Alpha3: "MKD", Numeric: "807", Name: "Denar"},
+	{Alpha2: "", Alpha3: "MMK", Numeric: "104", Name: "Kyat"},
+	{Alpha2: "", Alpha3: "MNT", Numeric: "496", Name: "Tugrik"},
+	{Alpha2: "", Alpha3: "MOP", Numeric: "446", Name: "Pataca"},
+	{Alpha2: "", Alpha3: "MRU", Numeric: "929", Name: "Ouguiya"},
+	{Alpha2: "", Alpha3: "MUR", Numeric: "480", Name: "Mauritius Rupee"},
+	{Alpha2: "", Alpha3: "MVR", Numeric: "462", Name: "Rufiyaa"},
+	{Alpha2: "", Alpha3: "MWK", Numeric: "454", Name: "Malawi Kwacha"},
+	{Alpha2: "", Alpha3: "MXN", Numeric: "484", Name: "Mexican Peso"},
+	{Alpha2: "", Alpha3: "MXV", Numeric: "979", Name: "Mexican Unidad de Inversion (UDI)"},
+	{Alpha2: "", Alpha3: "MYR", Numeric: "458", Name: "Malaysian Ringgit"},
+	{Alpha2: "", Alpha3: "MZN", Numeric: "943", Name: "Mozambique Metical"},
+	{Alpha2: "", Alpha3: "NAD", Numeric: "516", Name: "Namibia Dollar"},
+	{Alpha2: "", Alpha3: "NGN", Numeric: "566", Name: "Naira"},
+	{Alpha2: "", Alpha3: "NIO", Numeric: "558", Name: "Cordoba Oro"},
+	{Alpha2: "", Alpha3: "NOK", Numeric: "578", Name: "Norwegian Krone"},
+	{Alpha2: "", Alpha3: "NPR", Numeric: "524", Name: "Nepalese Rupee"},
+	{Alpha2: "", Alpha3: "NZD", Numeric: "554", Name: "New Zealand Dollar"},
+	{Alpha2: "", Alpha3: "OMR", Numeric: "512", Name: "Rial Omani"},
+	{Alpha2: "", Alpha3: "PAB", Numeric: "590", Name: "Balboa"},
+	{Alpha2: "", Alpha3: "PEN", Numeric: "604", Name: "Sol"},
+	{Alpha2: "", Alpha3: "PGK", Numeric: "598", Name: "Kina"},
+	{Alpha2: "", Alpha3: "PHP", Numeric: "608", Name: "Philippine Peso"},
+	{Alpha2: "", Alpha3: "PKR", Numeric: "586", Name: "Pakistan Rupee"},
+	{Alpha2: "", Alpha3: "PLN", Numeric: "985", Name: "Zloty"},
+	{Alpha2: "", Alpha3: "PYG", Numeric: "600", Name: "Guarani"},
+	{Alpha2: "", Alpha3: "QAR", Numeric: "634", Name: "Qatari Rial"},
+	{Alpha2: "", Alpha3: "RON", Numeric: "946", Name: "Romanian Leu"},
+	{Alpha2: "", Alpha3: "RSD", Numeric: "941", Name: "Serbian Dinar"},
+	{Alpha2: "", Alpha3: "RUB", Numeric: "643", Name: "Russian Ruble"},
+	{Alpha2: "", Alpha3: "RWF", Numeric: "646", Name: "Rwanda Franc"},
+	{Alpha2: "", Alpha3: "SAR", Numeric: "682", Name: "Saudi Riyal"},
+	{Alpha2: "", Alpha3: "SBD", Numeric: "090", Name: "Solomon Islands Dollar"},
+	{Alpha2: "", Alpha3: "SCR", Numeric: "690", Name: "Seychelles Rupee"},
+	{Alpha2: "", Alpha3: "SDG", Numeric: "938", Name: "Sudanese Pound"},
+	{Alpha2: "", Alpha3: "SEK", Numeric: "752", Name: "Swedish Krona"},
+	{Alpha2: "", Alpha3: "SGD", Numeric: "702", Name: "Singapore Dollar"},
+	{Alpha2: "", Alpha3: "SHP", Numeric: "654", Name: "Saint Helena Pound"},
+	{Alpha2: "", Alpha3: "SLE", Numeric: "925", Name: "Leone"},
+	{Alpha2: "", Alpha3: "SLL", Numeric: "694", Name: "Leone"},
+	{Alpha2: "", Alpha3: "SOS", Numeric: "706", Name: "Somali Shilling"},
+	{Alpha2: "", Alpha3: "SRD", Numeric: "968", Name: "Surinam Dollar"},
+	{Alpha2: "", Alpha3: "SSP", Numeric: "728", Name: "South Sudanese Pound"},
+	{Alpha2: "", Alpha3: "STN", Numeric: "930", Name: "Dobra"},
+	{Alpha2: "", Alpha3: "SVC", Numeric: "222", Name: "El Salvador Colon"},
+	{Alpha2: "", Alpha3: "SYP", Numeric: "760", Name: "Syrian Pound"},
+	{Alpha2: "", Alpha3: "SZL", Numeric: "748", Name: "Lilangeni"},
+	{Alpha2: "", Alpha3: "THB", Numeric: "764", Name: "Baht"},
+	{Alpha2: "", Alpha3: "TJS", Numeric: "972", Name: "Somoni"},
+	{Alpha2: "", Alpha3: "TMT", Numeric: "934", Name: "Turkmenistan New Manat"},
+	{Alpha2: "", Alpha3: "TND", Numeric: "788", Name: "Tunisian Dinar"},
+	{Alpha2: "", Alpha3: "TOP", Numeric: "776", Name: "Pa’anga"},
+	{Alpha2: "", Alpha3: "TRY", Numeric: "949", Name: "Turkish Lira"},
+	{Alpha2: "", Alpha3: "TTD", Numeric: "780", Name: "Trinidad and Tobago Dollar"},
+	{Alpha2: "", Alpha3: "TWD", Numeric: "901", Name: "New Taiwan Dollar"},
+	{Alpha2: "", Alpha3: "TZS", Numeric: "834", Name: "Tanzanian Shilling"},
+	{Alpha2: "", Alpha3: "UAH", Numeric: "980", Name: "Hryvnia"},
+	{Alpha2: "", Alpha3: "UGX", Numeric: "800", Name: "Uganda Shilling"},
+	{Alpha2: "", Alpha3: "USD", Numeric: "840", Name: "US Dollar"},
+	{Alpha2: "", Alpha3: "USN", Numeric: "997", Name: "US Dollar (Next day)"},
+	{Alpha2: "", Alpha3: "UYI", Numeric: "940", Name: "Uruguay Peso en Unidades Indexadas (UI)"},
+	{Alpha2: "", Alpha3: "UYU", Numeric: "858", Name: "Peso Uruguayo"},
+	{Alpha2: "", Alpha3: "UYW", Numeric: "927", Name: "Unidad Previsional"},
+	{Alpha2: "", Alpha3: "UZS", Numeric: "860", Name: "Uzbekistan Sum"},
+	{Alpha2: "", Alpha3: "VED", Numeric: "926", Name: "Bolívar Soberano"},
+	{Alpha2: "", Alpha3: "VES", Numeric: "928", Name: "Bolívar Soberano"},
+	{Alpha2: "", Alpha3: "VND", Numeric: "704", Name: "Dong"},
+	{Alpha2: "", Alpha3: "VUV", Numeric: "548", Name: "Vatu"},
+	{Alpha2: "", Alpha3: "WST", Numeric: "882", Name: "Tala"},
+	{Alpha2: "", Alpha3: "XAF", Numeric: "950", Name: "CFA Franc BEAC"},
+	{Alpha2: "", Alpha3: "XAG", Numeric: "961", Name: "Silver"},
+	{Alpha2: "", Alpha3: "XAU", Numeric: "959", Name: "Gold"},
+	{Alpha2: "", Alpha3: "XBA", Numeric: "955", Name: "Bond Markets Unit European Composite Unit (EURCO)"},
+	{Alpha2: "", Alpha3: "XBB", Numeric: "956", Name: "Bond Markets Unit European Monetary Unit (E.M.U.-6)"},
+	{Alpha2: "", Alpha3: "XBC", Numeric: "957", Name: "Bond Markets Unit European Unit of Account 9 (E.U.A.-9)"},
+	{Alpha2: "", Alpha3: "XBD", Numeric: "958", Name: "Bond Markets Unit European Unit of Account 17 (E.U.A.-17)"},
+	{Alpha2: "", Alpha3: "XCD", Numeric: "951", Name: "East Caribbean Dollar"},
+	{Alpha2: "", Alpha3: "XDR", Numeric: "960", Name: "SDR (Special Drawing Right)"},
+	{Alpha2: "", Alpha3: "XOF", Numeric: "952", Name: "CFA Franc BCEAO"},
+	{Alpha2: "", Alpha3: "XPD", Numeric: "964", Name: "Palladium"},
+	{Alpha2: "", Alpha3: "XPF", Numeric: "953", Name: "CFP Franc"},
+	{Alpha2: "", Alpha3: "XPT", Numeric: "962", Name: "Platinum"},
+	{Alpha2: "", Alpha3: "XSU", Numeric: "994", Name: "Sucre"},
+	{Alpha2: "", Alpha3: "XTS", Numeric: "963", Name: "Codes specifically reserved for testing purposes"},
+	{Alpha2: "", Alpha3: "XUA", Numeric: "965", Name: "ADB Unit of Account"},
+	{Alpha2: "", Alpha3: "XXX", Numeric: "999", Name: "The codes assigned for transactions where no currency is involved"},
+	{Alpha2: "", Alpha3: "YER", Numeric: "886", Name: "Yemeni Rial"},
+	{Alpha2: "", Alpha3: "ZAR", Numeric: "710", Name: "Rand"},
+	{Alpha2: "", Alpha3: "ZMW", Numeric: "967", Name: "Zambian Kwacha"},
+	{Alpha2: "", Alpha3: "ZWL", Numeric: "932", Name: "Zimbabwe Dollar"},
+}
+
+// languages is snapshot of ISO 639-1 and ISO 639-2 (487 entries)
+var languages = []Entry{
+	{Alpha2: "aa", Alpha3: "aar", Numeric: "", Name: "Afar"},
+	{Alpha2: "ab", Alpha3: "abk", Numeric: "", Name: "Abkhazian"},
+	{Alpha2: "", Alpha3: "ace", Numeric: "", Name: "Achinese"},
+	{Alpha2: "", Alpha3: "ach", Numeric: "", Name: "Acoli"},
+	{Alpha2: "", Alpha3: "ada", Numeric: "", Name: "Adangme"},
+	{Alpha2: "", Alpha3: "ady", Numeric: "", Name: "Adyghe"},
+	{Alpha2: "", Alpha3: "afa", Numeric: "", Name: "Afro-Asiatic languages"},
+	{Alpha2: "", Alpha3: "afh", Numeric: "", Name: "Afrihili"},
+	{Alpha2: "af", Alpha3: "afr", Numeric: "", Name: "Afrikaans"},
+	{Alpha2: "", Alpha3: "ain", Numeric: "", Name: "Ainu"},
+	{Alpha2: "ak", Alpha3: "aka", Numeric: "", Name: "Akan"},
+	{Alpha2: "", Alpha3: "akk", Numeric: "", Name: "Akkadian"},
+	{Alpha2: "", Alpha3: "ale", Numeric: "", Name: "Aleut"},
+	{Alpha2: "", Alpha3: "alg", Numeric: "", Name: "Algonquian languages"},
+	{Alpha2: "", Alpha3: "alt", Numeric: "", Name: "Southern Altai"},
+	{Alpha2: "am", Alpha3: "amh", Numeric: "", Name: "Amharic"},
+	{Alpha2: "", Alpha3: "ang", Numeric: "", Name: "English, Old (ca. 450-1100)"},
+	{Alpha2: "", Alpha3: "anp", Numeric: "", Name: "Angika"},
+	{Alpha2: "", Alpha3: "apa", Numeric: "", Name: "Apache languages"},
+	{Alpha2: "ar", Alpha3: "ara", Numeric: "", Name: "Arabic"},
+	{Alpha2: "", Alpha3: "arc", Numeric: "", Name: "Official Aramaic (700-300 BCE)"},
+	{Alpha2: "an", Alpha3: "arg", Numeric: "", Name: "Aragonese"},
+	{Alpha2: "", Alpha3: "arn", Numeric: "", Name: "Mapudungun"},
+	{Alpha2: "", Alpha3: "arp", Numeric: "", Name: "Arapaho"},
+	{Alpha2: "", Alpha3: "art", Numeric: "", Name: "Artificial languages"},
+	{Alpha2: "", Alpha3: "arw", Numeric: "", Name: "Arawak"},
+	{Alpha2: "as", Alpha3: "asm", Numeric: "", Name: "Assamese"},
+	{Alpha2: "", Alpha3: "ast", Numeric: "", Name: "Asturian"},
+	{Alpha2: "", Alpha3: "ath", Numeric: "", Name: "Athapascan languages"},
+	{Alpha2: "", Alpha3: "aus", Numeric: "", Name: "Australian languages"},
+	{Alpha2: "av", Alpha3: "ava", Numeric: "", Name: "Avaric"},
+	{Alpha2: "ae", Alpha3: "ave", Numeric: "", Name: "Avestan"},
+	{Alpha2: "", Alpha3: "awa", Numeric: "", Name: "Awadhi"},
+	{Alpha2: "ay", Alpha3: "aym", Numeric: "", Name: "Aymara"},
+	{Alpha2: "az", Alpha3: "aze", Numeric: "", Name: "Azerbaijani"},
+	{Alpha2: "", Alpha3: "bad", Numeric: "", Name: "Banda languages"},
+	{Alpha2: "", Alpha3: "bai", Numeric: "", Name: "Bamileke languages"},
+	{Alpha2: "ba", Alpha3: "bak", Numeric: "", Name: "Bashkir"},
+	{Alpha2: "", Alpha3: "bal", Numeric: "", Name: "Baluchi"},
+	{Alpha2: "bm", Alpha3: "bam", Numeric: "", Name: "Bambara"},
+	{Alpha2: "", Alpha3: "ban", Numeric: "", Name: "Balinese"},
+	{Alpha2: "", Alpha3: "bas", Numeric: "", Name: "Basa"},
+	{Alpha2: "", Alpha3: "bat", Numeric: "", Name: "Baltic languages"},
+	{Alpha2: "", Alpha3: "bej", Numeric: "", Name: "Beja"},
+	{Alpha2: "be", Alpha3: "bel", Numeric: "", Name: "Belarusian"},
+	{Alpha2: "", Alpha3: "bem", Numeric: "", Name: "Bemba"},
+	{Alpha2: "bn", Alpha3: "ben", Numeric: "", Name: "Bengali"},
+	{Alpha2: "", Alpha3: "ber", Numeric: "", Name: "Berber languages"},
+	{Alpha2: "", Alpha3: "bho", Numeric: "", Name: "Bhojpuri"},
+	{Alpha2: "bh", Alpha3: "bih", Numeric: "", Name: "Bihari languages"},
+	{Alpha2: "", Alpha3: "bik", Numeric: "", Name: "Bikol"},
+	{Alpha2: "", Alpha3: "bin", Numeric: "", Name: "Bini"},
+	{Alpha2: "bi", Alpha3: "bis", Numeric: "", Name: "Bislama"},
+	{Alpha2: "", Alpha3: "bla", Numeric: "", Name: "Siksika"},
+	{Alpha2: "", Alpha3: "bnt", Numeric: "", Name: "Bantu (Other)"},
+	{Alpha2: "bo", Alpha3: "bod", Numeric: "", Name: "Tibetan"},
+	{Alpha2: "bs", Alpha3: "bos", Numeric: "", Name: "Bosnian"},
+	{Alpha2: "", Alpha3: "bra", Numeric: "", Name: "Braj"},
+	{Alpha2: "br", Alpha3: "bre", Numeric: "", Name: "Breton"},
+	{Alpha2: "", Alpha3: "btk", Numeric: "", Name: "Batak languages"},
+	{Alpha2: "", Alpha3: "bua", Numeric: "", Name: "Buriat"},
+	{Alpha2: "", Alpha3: "bug", Numeric: "", Name: "Buginese"},
+	{Alpha2: "bg", Alpha3: "bul", Numeric: "", Name: "Bulgarian"},
+	{Alpha2: "", Alpha3: "byn", Numeric: "", Name: "Blin"},
+	{Alpha2: "", Alpha3: "cad", Numeric: "", Name: "Caddo"},
+	{Alpha2: "", Alpha3: "cai", Numeric: "", Name: "Central American Indian languages"},
+	{Alpha2: "", Alpha3: "car", Numeric: "", Name: "Galibi Carib"},
+	{Alpha2: "ca", Alpha3: "cat", Numeric: "", Name: "Catalan"},
+	{Alpha2: "", Alpha3: "cau", Numeric: "", Name: "Caucasian languages"},
+	{Alpha2: "", Alpha3: "ceb", Numeric: "", Name: "Cebuano"},
+	{Alpha2: "", Alpha3: "cel", Numeric: "", Name: "Celtic languages"},
+	{Alpha2: "cs", Alpha3: "ces", Numeric: "", Name: "Czech"},
+	{Alpha2: "ch", Alpha3: "cha", Numeric: "", Name: "Chamorro"},
+	{Alpha2: "", Alpha3: "chb", Numeric: "", Name: "Chibcha"},
+	{Alpha2: "ce", Alpha3: "che", Numeric: "", Name: "Chechen"},
+	{Alpha2: "", Alpha3: "chg", Numeric: "", Name: "Chagatai"},
+	{Alpha2: "", Alpha3: "chk", Numeric: "", Name: "Chuukese"},
+	{Alpha2: "", Alpha3: "chm", Numeric: "", Name: "Mari"},
+	{Alpha2: "", Alpha3: "chn", Numeric: "", Name: "Chinook jargon"},
+	{Alpha2: "", Alpha3: "cho", Numeric: "", Name: "Choctaw"},
+	{Alpha2: "", Alpha3: "chp", Numeric: "", Name: "Chipewyan"},
+	{Alpha2: "", Alpha3: "chr", Numeric: "", Name: "Cherokee"},
+	{Alpha2: "cu", Alpha3: "chu", Numeric: "", Name: "Church Slavic"},
+	{Alpha2: "cv", Alpha3: "chv", Numeric: "", Name: "Chuvash"},
+	{Alpha2: "", Alpha3: "chy", Numeric: "", Name: "Cheyenne"},
+	{Alpha2: "", Alpha3: "cmc", Numeric: "", Name: "Chamic languages"},
+	{Alpha2: "", Alpha3: "cnr", Numeric: "", Name: "Montenegrin"},
+	{Alpha2: "", Alpha3: "cop", Numeric: "", Name: "Coptic"},
+	{Alpha2: "kw", Alpha3: "cor", Numeric: "", Name: "Cornish"},
+	{Alpha2: "co", Alpha3: "cos", Numeric: "", Name: "Corsican"},
+	{Alpha2: "", Alpha3: "cpe", Numeric: "", Name: "Creoles and pidgins, English based"},
+	{Alpha2: "", Alpha3: "cpf", Numeric: "", Name: "Creoles and pidgins, French-based"},
+	{Alpha2: "", Alpha3: "cpp", Numeric: "", Name: "Creoles and pidgins, Portuguese-based"},
+	{Alpha2: "cr", Alpha3: "cre", Numeric: "", Name: "Cree"},
+	{Alpha2: "", Alpha3: "crh", Numeric: "", Name: "Crimean Tatar"},
+	{Alpha2: "", Alpha3: "crp", Numeric: "", Name: "Creoles and pidgins"},
+	{Alpha2: "", Alpha3: "csb", Numeric: "", Name: "Kashubian"},
+	{Alpha2: "", Alpha3: "cus", Numeric: "", Name: "Cushitic languages"},
+	{Alpha2: "cy", Alpha3: "cym", Numeric: "", Name: "Welsh"},
+	{Alpha2: "", Alpha3: "dak", Numeric: "", Name: "Dakota"},
+	{Alpha2: "da", Alpha3: "dan", Numeric: "", Name: "Danish"},
+	{Alpha2: "", Alpha3: "dar", Numeric: "", Name: "Dargwa"},
+	{Alpha2: "", Alpha3: "day", Numeric: "", Name: "Land Dayak languages"},
+	{Alpha2: "", Alpha3: "del", Numeric: "", Name: "Delaware"},
+	{Alpha2: "", Alpha3: "den", Numeric: "", Name: "Slave (Athapascan)"},
+	{Alpha2: "de", Alpha3: "deu", Numeric: "", Name: "German"},
+	{Alpha2: "", Alpha3: "dgr", Numeric: "", Name: "Dogrib"},
+	{Alpha2: "", Alpha3: "din", Numeric: "", Name: "Dinka"},
+	{Alpha2: "dv", Alpha3: "div", Numeric: "", Name: "Divehi"},
+	{Alpha2: "", Alpha3: "doi", Numeric: "", Name: "Dogri"},
+	{Alpha2: "", Alpha3: "dra", Numeric: "", Name: "Dravidian languages"},
+	{Alpha2: "", Alpha3: "dsb", Numeric: "", Name: "Lower Sorbian"},
+	{Alpha2: "", Alpha3: "dua", Numeric: "", Name: "Duala"},
+	{Alpha2: "", Alpha3: "dum", Numeric: "", Name: "Dutch, Middle (ca. 1050-1350)"},
+	{Alpha2: "", Alpha3: "dyu", Numeric: "", Name: "Dyula"},
+	{Alpha2: "dz", Alpha3: "dzo", Numeric: "", Name: "Dzongkha"},
+	{Alpha2: "", Alpha3: "efi", Numeric: "", Name: "Efik"},
+	{Alpha2: "", Alpha3: "egy", Numeric: "", Name: "Egyptian (Ancient)"},
+	{Alpha2: "", Alpha3: "eka", Numeric: "", Name: "Ekajuk"},
+	{Alpha2: "el", Alpha3: "ell", Numeric: "", Name: "Greek, Modern (1453-)"},
+	{Alpha2: "", Alpha3: "elx", Numeric: "", Name: "Elamite"},
+	{Alpha2: "en", Alpha3: "eng", Numeric: "", Name: "English"},
+	{Alpha2: "", Alpha3: "enm", Numeric: "", Name: "English, Middle (1100-1500)"},
+	{Alpha2: "eo", Alpha3: "epo", Numeric: "", Name: "Esperanto"},
+	{Alpha2: "et", Alpha3: "est", Numeric: "", Name: "Estonian"},
+	{Alpha2: "eu", Alpha3: "eus", Numeric: "", Name: "Basque"},
+	{Alpha2: "ee", Alpha3: "ewe", Numeric: "", Name: "Ewe"},
+	{Alpha2: "", Alpha3: "ewo", Numeric: "", Name: "Ewondo"},
+	{Alpha2: "", Alpha3: "fan", Numeric: "", Name: "Fang"},
+	{Alpha2: "fo", Alpha3: "fao", Numeric: "", Name: "Faroese"},
+	{Alpha2: "fa", Alpha3: "fas", Numeric: "", Name: "Persian"},
+	{Alpha2: "", Alpha3: "fat", Numeric: "", Name: "Fanti"},
+	{Alpha2: "fj", Alpha3: "fij", Numeric: "", Name: "Fijian"},
+	{Alpha2: "", Alpha3: "fil", Numeric: "", Name: "Filipino"},
+	{Alpha2: "fi", Alpha3: "fin", Numeric: "", Name: "Finnish"},
+	{Alpha2: "", Alpha3: "fiu", Numeric: "", Name: "Finno-Ugrian languages"},
+	{Alpha2: "", Alpha3: "fon", Numeric: "", Name: "Fon"},
+	{Alpha2: "fr", Alpha3: "fra", Numeric: "", Name: "French"},
+	{Alpha2: "", Alpha3: "frm", Numeric: "", Name: "French, Middle (ca. 1400-1600)"},
+	{Alpha2: "", Alpha3: "fro", Numeric: "", Name: "French, Old (842-ca. 1400)"},
+	{Alpha2: "", Alpha3: "frr", Numeric: "", Name: "Northern Frisian"},
+	{Alpha2: "", Alpha3: "frs", Numeric: "", Name: "Eastern Frisian"},
+	{Alpha2: "fy", Alpha3: "fry", Numeric: "", Name: "Western Frisian"},
+	{Alpha2: "ff", Alpha3: "ful", Numeric: "", Name: "Fulah"},
+	{Alpha2: "", Alpha3: "fur", Numeric: "", Name: "Friulian"},
+	{Alpha2: "", Alpha3: "gaa", Numeric: "", Name: "Ga"},
+	{Alpha2: "", Alpha3: "gay", Numeric: "", Name: "Gayo"},
+	{Alpha2: "", Alpha3: "gba", Numeric: "", Name: "Gbaya"},
+	{Alpha2: "", Alpha3: "gem", Numeric: "", Name: "Germanic languages"},
+	{Alpha2: "", Alpha3: "gez", Numeric: "", Name: "Geez"},
+	{Alpha2: "", Alpha3: "gil", Numeric: "", Name: "Gilbertese"},
+	{Alpha2: "gd", Alpha3: "gla", Numeric: "", Name: "Gaelic"},
+	{Alpha2: "ga", Alpha3: "gle", Numeric: "", Name: "Irish"},
+	{Alpha2: "gl", Alpha3: "glg", Numeric: "", Name: "Galician"},
+	{Alpha2: "gv", Alpha3: "glv", Numeric: "", Name: "Manx"},
+	{Alpha2: "", Alpha3: "gmh", Numeric: "", Name: "German, Middle High (ca. 1050-1500)"},
+	{Alpha2: "", Alpha3: "goh", Numeric: "", Name: "German, Old High (ca. 750-1050)"},
+	{Alpha2: "", Alpha3: "gon", Numeric: "", Name: "Gondi"},
+	{Alpha2: "", Alpha3: "gor", Numeric: "", Name: "Gorontalo"},
+	{Alpha2: "", Alpha3: "got", Numeric: "", Name: "Gothic"},
+	{Alpha2: "", Alpha3: "grb", Numeric: "", Name: "Grebo"},
+	{Alpha2: "", Alpha3: "grc", Numeric: "", Name: "Greek, Ancient (to 1453)"},
+	{Alpha2: "gn", Alpha3: "grn", Numeric: "", Name: "Guarani"},
+	{Alpha2: "", Alpha3: "gsw", Numeric: "", Name: "Swiss German"},
+	{Alpha2: "gu", Alpha3: "guj", Numeric: "", Name: "Gujarati"},
+	{Alpha2: "", Alpha3: "gwi", Numeric: "", Name: "Gwich'in"},
+	{Alpha2: "", Alpha3: "hai", Numeric: "", Name: "Haida"},
+	{Alpha2: "ht", Alpha3: "hat", Numeric: "", Name: "Haitian"},
+	{Alpha2: "ha", Alpha3: "hau", Numeric: "", Name: "Hausa"},
+	{Alpha2: "", Alpha3: "haw", Numeric: "", Name: "Hawaiian"},
+	{Alpha2: "he", Alpha3: "heb", Numeric: "", Name: "Hebrew"},
+	{Alpha2: "hz", Alpha3: "her", Numeric: "", Name: "Herero"},
+	{Alpha2: "", Alpha3: "hil", Numeric: "", Name: "Hiligaynon"},
+	{Alpha2: "", Alpha3: "him", Numeric: "", Name: "Himachali languages"},
+	{Alpha2: "hi", Alpha3: "hin", Numeric: "", Name: "Hindi"},
+	{Alpha2: "", Alpha3: "hit", Numeric: "", Name: "Hittite"},
+	{Alpha2: "", Alpha3: "hmn", Numeric: "", Name: "Hmong"},
+	{Alpha2: "ho", Alpha3: "hmo", Numeric: "", Name: "Hiri Motu"},
+	{Alpha2: "hr", Alpha3: "hrv", Numeric: "", Name: "Croatian"},
+	{Alpha2: "", Alpha3: "hsb", Numeric: "", Name: "Upper Sorbian"},
+	{Alpha2: "hu", Alpha3: "hun", Numeric: "", Name: "Hungarian"},
+	{Alpha2: "", Alpha3: "hup", Numeric: "", Name: "Hupa"},
+	{Alpha2: "hy", Alpha3: "hye", Numeric: "", Name: "Armenian"},
+	{Alpha2: "", Alpha3: "iba", Numeric: "", Name: "Iban"},
+	{Alpha2: "ig", Alpha3: "ibo", Numeric: "", Name: "Igbo"},
+	{Alpha2: "io", Alpha3: "ido", Numeric: "", Name: "Ido"},
+	{Alpha2: "ii", Alpha3: "iii", Numeric: "", Name: "Sichuan Yi"},
+	{Alpha2: "", Alpha3: "ijo", Numeric: "", Name: "Ijo languages"},
+	{Alpha2: "iu", Alpha3: "iku", Numeric: "", Name: "Inuktitut"},
+	{Alpha2: "ie", Alpha3: "ile", Numeric: "", Name: "Interlingue"},
+	{Alpha2: "", Alpha3: "ilo", Numeric: "", Name: "Iloko"},
+	{Alpha2: "ia", Alpha3: "ina", Numeric: "", Name: "Interlingua (International Auxiliary Language Association)"},
+	{Alpha2: "", Alpha3: "inc", Numeric: "", Name: "Indic languages"},
+	{Alpha2: "id", Alpha3: "ind", Numeric: "", Name: "Indonesian"},
+	{Alpha2: "", Alpha3: "ine", Numeric: "", Name: "Indo-European languages"},
+	{Alpha2: "", Alpha3: "inh", Numeric: "", Name: "Ingush"},
+	{Alpha2: "ik", Alpha3: "ipk", Numeric: "", Name: "Inupiaq"},
+	{Alpha2: "", Alpha3: "ira", Numeric: "", Name: "Iranian languages"},
+	{Alpha2: "", Alpha3: "iro", Numeric: "", Name: "Iroquoian languages"},
+	{Alpha2: "is", Alpha3: "isl", Numeric: "", Name: "Icelandic"},
+	{Alpha2: "it", Alpha3: "ita", Numeric: "", Name: "Italian"},
+	{Alpha2: "jv", Alpha3: "jav", Numeric: "", Name: "Javanese"},
+	{Alpha2: "", Alpha3: "jbo", Numeric: "", Name: "Lojban"},
+	{Alpha2: "ja", Alpha3: "jpn", Numeric: "", Name: "Japanese"},
+	{Alpha2: "", Alpha3: "jpr", Numeric: "", Name: "Judeo-Persian"},
+	{Alpha2: "", Alpha3: "jrb", Numeric: "", Name: "Judeo-Arabic"},
+	{Alpha2: "", Alpha3: "kaa", Numeric: "", Name: "Kara-Kalpak"},
+	{Alpha2: "", Alpha3: "kab", Numeric: "", Name: "Kabyle"},
+	{Alpha2: "", Alpha3: "kac", Numeric: "", Name: "Kachin"},
+	{Alpha2: "kl", Alpha3: "kal", Numeric: "", Name: "Kalaallisut"},
+	{Alpha2: "", Alpha3: "kam", Numeric: "", Name: "Kamba"},
+	{Alpha2: "kn", Alpha3: "kan", Numeric: "", Name: "Kannada"},
+	{Alpha2: "", Alpha3: "kar", Numeric: "", Name: "Karen languages"},
+	{Alpha2: "ks", Alpha3: "kas", Numeric: "", Name: "Kashmiri"},
+	{Alpha2: "ka", Alpha3: "kat", Numeric: "", Name: "Georgian"},
+	{Alpha2: "kr", Alpha3: "kau", Numeric: "", Name: "Kanuri"},
+	{Alpha2: "", Alpha3: "kaw", Numeric: "", Name: "Kawi"},
+	{Alpha2: "kk", Alpha3: "kaz", Numeric: "", Name: "Kazakh"},
+	{Alpha2: "", Alpha3: "kbd", Numeric: "", Name: "Kabardian"},
+	{Alpha2: "", Alpha3: "kha", Numeric: "", Name: "Khasi"},
+	{Alpha2: "", Alpha3: "khi", Numeric: "", Name: "Khoisan languages"},
+	{Alpha2: "km", Alpha3: "khm", Numeric: "", Name: "Central Khmer"},
+	{Alpha2: "", Alpha3: "kho", Numeric: "", Name: "Khotanese"},
+	{Alpha2: "ki", Alpha3: "kik", Numeric: "", Name: "Kikuyu"},
+	{Alpha2: "rw", Alpha3: "kin", Numeric: "", Name: "Kinyarwanda"},
+	{Alpha2: "ky", Alpha3: "kir", Numeric: "", Name: "Kirghiz"},
+	{Alpha2: "", Alpha3: "kmb", Numeric: "", Name: "Kimbundu"},
+	{Alpha2: "", Alpha3: "kok", Numeric: "", Name: "Konkani"},
+	{Alpha2: "kv", Alpha3: "kom", Numeric: "", Name: "Komi"},
+	{Alpha2: "kg", Alpha3: "kon", Numeric: "", Name: "Kongo"},
+	{Alpha2: "ko", Alpha3: "kor", Numeric: "", Name: "Korean"},
+	{Alpha2: "", Alpha3: "kos", Numeric: "", Name: "Kosraean"},
+	{Alpha2: "", Alpha3: "kpe", Numeric: "", Name: "Kpelle"},
+	{Alpha2: "", Alpha3: "krc", Numeric: "", Name: "Karachay-Balkar"},
+	{Alpha2: "", Alpha3: "krl", Numeric: "", Name: "Karelian"},
+	{Alpha2: "", Alpha3: "kro", Numeric: "", Name: "Kru languages"},
+	{Alpha2: "", Alpha3: "kru", Numeric: "", Name: "Kurukh"},
+	{Alpha2: "kj", Alpha3: "kua", Numeric: "", Name: "Kuanyama"},
+	{Alpha2: "", Alpha3: "kum", Numeric: "", Name: "Kumyk"},
+	{Alpha2: "ku", Alpha3: "kur", Numeric: "", Name: "Kurdish"},
+	{Alpha2: "", Alpha3: "kut", Numeric: "", Name: "Kutenai"},
+	{Alpha2: "", Alpha3: "lad", Numeric: "", Name: "Ladino"},
+	{Alpha2: "", Alpha3: "lah", Numeric: "", Name: "Lahnda"},
+	{Alpha2: "", Alpha3: "lam", Numeric: "", Name: "Lamba"},
+	{Alpha2: "lo", Alpha3: "lao", Numeric: "", Name: "Lao"},
+	{Alpha2: "la", Alpha3: "lat", Numeric: "", Name: "Latin"},
+	{Alpha2: "lv", Alpha3: "lav", Numeric: "", Name: "Latvian"},
+	{Alpha2: "", Alpha3: "lez", Numeric: "", Name: "Lezghian"},
+	{Alpha2: "li", Alpha3: "lim", Numeric: "", Name: "Limburgan"},
+	{Alpha2: "ln", Alpha3: "lin", Numeric: "", Name: "Lingala"},
+	{Alpha2: "lt", Alpha3: "lit", Numeric: "", Name: "Lithuanian"},
+	{Alpha2: "", Alpha3: "lol", Numeric: "", Name: "Mongo"},
+	{Alpha2: "", Alpha3: "loz", Numeric: "", Name: "Lozi"},
+	{Alpha2: "lb", Alpha3: "ltz", Numeric: "", Name: "Luxembourgish"},
+	{Alpha2: "", Alpha3: "lua", Numeric: "", Name: "Luba-Lulua"},
+	{Alpha2: "lu", Alpha3: "lub", Numeric: "", Name: "Luba-Katanga"},
+	{Alpha2: "lg", Alpha3: "lug", Numeric: "", Name: "Ganda"},
+	{Alpha2: "", Alpha3: "lui", Numeric: "", Name: "Luiseno"},
+	{Alpha2: "", Alpha3: "lun", Numeric: "", Name: "Lunda"},
+	{Alpha2: "", Alpha3: "luo", Numeric: "", Name: "Luo (Kenya and Tanzania)"},
+	{Alpha2: "", Alpha3: "lus", Numeric: "", Name: "Lushai"},
+	{Alpha2: "", Alpha3: "mad", Numeric: "", Name: "Madurese"},
+	{Alpha2: "", Alpha3: "mag", Numeric: "", Name: "Magahi"},
+	{Alpha2: "mh", Alpha3: "mah", Numeric: "", Name: "Marshallese"},
+	{Alpha2: "", Alpha3: "mai", Numeric: "", Name: "Maithili"},
+	{Alpha2: "", Alpha3: "mak", Numeric: "", Name: "Makasar"},
+	{Alpha2: "ml", Alpha3: "mal", Numeric: "", Name: "Malayalam"},
+	{Alpha2: "", Alpha3: "man", Numeric: "", Name: "Mandingo"},
+	{Alpha2: "", Alpha3: "map", Numeric: "", Name: "Austronesian languages"},
+	{Alpha2: "mr", Alpha3: "mar", Numeric: "", Name: "Marathi"},
+	{Alpha2: "", Alpha3: "mas", Numeric: "", Name: "Masai"},
+	{Alpha2: "", Alpha3: "mdf", Numeric: "", Name: "Moksha"},
+	{Alpha2: "", Alpha3: "mdr", Numeric: "", Name: "Mandar"},
+	{Alpha2: "", Alpha3: "men", Numeric: "", Name: "Mende"},
+	{Alpha2: "", Alpha3: "mga", Numeric: "", Name: "Irish, Middle (900-1200)"},
+	{Alpha2: "", Alpha3: "mic", Numeric: "", Name: "Mi'kmaq"},
+	{Alpha2: "", Alpha3: "min", Numeric: "", Name: "Minangkabau"},
+	{Alpha2: "", Alpha3: "mis", Numeric: "", Name: "Uncoded languages"},
+	{Alpha2: "mk", Alpha3: "mkd", Numeric: "", Name: "Macedonian"},
+	{Alpha2: "", Alpha3: "mkh", Numeric: "", Name: "Mon-Khmer languages"},
+	{Alpha2: "mg", Alpha3: "mlg", Numeric: "", Name: "Malagasy"},
+	{Alpha2: "mt", Alpha3: "mlt", Numeric: "", Name: "Maltese"},
+	{Alpha2: "", Alpha3: "mnc", Numeric: "", Name: "Manchu"},
+	{Alpha2: "", Alpha3: "mni", Numeric: "", Name: "Manipuri"},
+	{Alpha2: "", Alpha3: "mno", Numeric: "", Name: "Manobo languages"},
+	{Alpha2: "", Alpha3: "moh", Numeric: "", Name: "Mohawk"},
+	{Alpha2: "mn", Alpha3: "mon", Numeric: "", Name: "Mongolian"},
+	{Alpha2: "", Alpha3: "mos", Numeric: "", Name: "Mossi"},
+	{Alpha2: "mi", Alpha3: "mri", Numeric: "", Name: "Maori"},
+	{Alpha2: "ms", Alpha3: "msa", Numeric: "", Name: "Malay"},
+	{Alpha2: "", Alpha3: "mul", Numeric: "", Name: "Multiple languages"},
+	{Alpha2: "", Alpha3: "mun", Numeric: "", Name: "Munda languages"},
+	{Alpha2: "", Alpha3: "mus", Numeric: "", Name: "Creek"},
+	{Alpha2: "", Alpha3: "mwl", Numeric: "", Name: "Mirandese"},
+	{Alpha2: "", Alpha3: "mwr", Numeric: "", Name: "Marwari"},
+	{Alpha2: "my", Alpha3: "mya", Numeric: "", Name: "Burmese"},
+	{Alpha2: "", Alpha3: "myn", Numeric: "", Name: "Mayan languages"},
+	{Alpha2: "", Alpha3: "myv", Numeric: "", Name: "Erzya"},
+	{Alpha2: "", Alpha3: "nah", Numeric: "", Name: "Nahuatl languages"},
+	{Alpha2: "", Alpha3: "nai", Numeric: "", Name: "North American Indian languages"},
+	{Alpha2: "", Alpha3: "nap", Numeric: "", Name: "Neapolitan"},
+	{Alpha2: "na", Alpha3: "nau", Numeric: "", Name: "Nauru"},
+	{Alpha2: "nv", Alpha3: "nav", Numeric: "", Name: "Navajo"},
+	{Alpha2: "nr", Alpha3: "nbl", Numeric: "", Name: "Ndebele, South"},
+	{Alpha2: "nd", Alpha3: "nde", Numeric: "", Name: "Ndebele, North"},
+	{Alpha2: "ng", Alpha3: "ndo", Numeric: "", Name: "Ndonga"},
+	{Alpha2: "", Alpha3: "nds", Numeric: "", Name: "Low German"},
+	{Alpha2: "ne", Alpha3: "nep", Numeric: "", Name: "Nepali"},
+	{Alpha2: "", Alpha3: "new", Numeric: "", Name: "Nepal Bhasa"},
+	{Alpha2: "", Alpha3: "nia", Numeric: "", Name: "Nias"},
+	{Alpha2: "", Alpha3: "nic", Numeric: "", Name: "Niger-Kordofanian languages"},
+	{Alpha2: "", Alpha3: "niu", Numeric: "", Name: "Niuean"},
+	{Alpha2: "nl", Alpha3: "nld", Numeric: "", Name: "Dutch"},
+	{Alpha2: "nn", Alpha3: "nno", Numeric: "", Name: "Norwegian Nynorsk"},
+	{Alpha2: "nb", Alpha3: "nob", Numeric: "", Name: "Bokmål, Norwegian"},
+	{Alpha2: "", Alpha3: "nog", Numeric: "", Name: "Nogai"},
+	{Alpha2: "", Alpha3: "non", Numeric: "", Name: "Norse, Old"},
+	{Alpha2: "no", Alpha3: "nor", Numeric: "", Name: "Norwegian"},
+	{Alpha2: "", Alpha3: "nqo", Numeric: "", Name: "N'Ko"},
+	{Alpha2: "", Alpha3: "nso", Numeric: "", Name: "Pedi"},
+	{Alpha2: "", Alpha3: "nub", Numeric: "", Name: "Nubian languages"},
+	{Alpha2: "", Alpha3: "nwc", Numeric: "", Name: "Classical Newari"},
+	{Alpha2: "ny", Alpha3: "nya", Numeric: "", Name: "Chichewa"},
+	{Alpha2: "", Alpha3: "nym", Numeric: "", Name: "Nyamwezi"},
+	{Alpha2: "", Alpha3: "nyn", Numeric: "", Name: "Nyankole"},
+	{Alpha2: "", Alpha3: "nyo", Numeric: "", Name: "Nyoro"},
+	{Alpha2: "", Alpha3: "nzi", Numeric: "", Name: "Nzima"},
+	{Alpha2: "oc", Alpha3: "oci", Numeric: "", Name: "Occitan (post 1500)"},
+	{Alpha2: "oj", Alpha3: "oji", Numeric: "", Name: "Ojibwa"},
+	{Alpha2: "or", Alpha3: "ori", Numeric: "", Name: "Oriya"},
+	{Alpha2: "om", Alpha3: "orm", Numeric: "", Name: "Oromo"},
+	{Alpha2: "", Alpha3: "osa", Numeric: "", Name: "Osage"},
+	{Alpha2: "os", Alpha3: "oss", Numeric: "", Name: "Ossetian"},
+	{Alpha2: "", Alpha3: "ota", Numeric: "", Name: "Turkish, Ottoman (1500-1928)"},
+	{Alpha2: "", Alpha3: "oto", Numeric: "", Name: "Otomian languages"},
+	{Alpha2: "", Alpha3: "paa", Numeric: "", Name: "Papuan languages"},
+	{Alpha2: "", Alpha3: "pag", Numeric: "", Name: "Pangasinan"},
+	{Alpha2: "", Alpha3: "pal", Numeric: "", Name: "Pahlavi"},
+	{Alpha2: "", Alpha3: "pam", Numeric: "", Name: "Pampanga"},
+	{Alpha2: "pa", Alpha3: "pan", Numeric: "", Name: "Panjabi"},
+	{Alpha2: "", Alpha3: "pap", Numeric: "", Name: "Papiamento"},
+	{Alpha2: "", Alpha3: "pau", Numeric: "", Name: "Palauan"},
+	{Alpha2: "", Alpha3: "peo", Numeric: "", Name: "Persian, Old (ca. 600-400 B.C.)"},
+	{Alpha2: "", Alpha3: "phi", Numeric: "", Name: "Philippine languages"},
+	{Alpha2: "", Alpha3: "phn", Numeric: "", Name: "Phoenician"},
+	{Alpha2: "pi", Alpha3: "pli", Numeric: "", Name: "Pali"},
+	{Alpha2: "pl", Alpha3: "pol", Numeric: "", Name: "Polish"},
+	{Alpha2: "", Alpha3: "pon", Numeric: "", Name: "Pohnpeian"},
+	{Alpha2: "pt", Alpha3: "por", Numeric: "", Name: "Portuguese"},
+	{Alpha2: "", Alpha3: "pra", Numeric: "", Name: "Prakrit languages"},
+	{Alpha2: "", Alpha3: "pro", Numeric: "", Name: "Provençal, Old (to 1500)"},
+	{Alpha2: "ps", Alpha3: "pus", Numeric: "", Name: "Pushto"},
+	{Alpha2: "", Alpha3: "qaa-qtz", Numeric: "", Name: "Reserved for local use"},
+	{Alpha2: "qu", Alpha3: "que", Numeric: "", Name: "Quechua"},
+	{Alpha2: "", Alpha3: "raj", Numeric: "", Name: "Rajasthani"},
+	{Alpha2: "", Alpha3: "rap", Numeric: "", Name: "Rapanui"},
+	{Alpha2: "", Alpha3: "rar", Numeric: "", Name: "Rarotongan"},
+	{Alpha2: "", Alpha3: "roa", Numeric: "", Name: "Romance languages"},
+	{Alpha2: "rm", Alpha3: "roh", Numeric: "", Name: "Romansh"},
+	{Alpha2: "", Alpha3: "rom", Numeric: "", Name: "Romany"},
+	{Alpha2: "ro", Alpha3: "ron", Numeric: "", Name: "Romanian"},
+	{Alpha2: "rn", Alpha3: "run", Numeric: "", Name: "Rundi"},
+	{Alpha2: "", Alpha3: "rup", Numeric: "", Name: "Aromanian"},
+	{Alpha2: "ru", Alpha3: "rus", Numeric: "", Name: "Russian"},
+	{Alpha2: "", Alpha3: "sad", Numeric: "", Name: "Sandawe"},
+	{Alpha2: "sg", Alpha3: "sag", Numeric: "", Name: "Sango"},
+	{Alpha2: "", Alpha3: "sah", Numeric: "", Name: "Yakut"},
+	{Alpha2: "", Alpha3: "sai", Numeric: "", Name: "South American Indian (Other)"},
+	{Alpha2: "", Alpha3: "sal", Numeric: "", Name: "Salishan languages"},
+	{Alpha2: "", Alpha3: "sam", Numeric: "", Name: "Samaritan Aramaic"},
+	{Alpha2: "sa", Alpha3: "san", Numeric: "", Name: "Sanskrit"},
+	{Alpha2: "", Alpha3: "sas", Numeric: "", Name: "Sasak"},
+	{Alpha2: "", Alpha3: "sat", Numeric: "", Name: "Santali"},
+	{Alpha2: "", Alpha3: "scn", Numeric: "", Name: "Sicilian"},
+	{Alpha2: "", Alpha3: "sco", Numeric: "", Name: "Scots"},
+	{Alpha2: "", Alpha3: "sel", Numeric: "", Name: "Selkup"},
+	{Alpha2: "", Alpha3: "sem", Numeric: "", Name: "Semitic languages"},
+	{Alpha2: "", Alpha3: "sga", Numeric: "", Name: "Irish, Old (to 900)"},
+	{Alpha2: "", Alpha3: "sgn", Numeric: "", Name: "Sign Languages"},
+	{Alpha2: "", Alpha3: "shn", Numeric: "", Name: "Shan"},
+	{Alpha2: "", Alpha3: "sid", Numeric: "", Name: "Sidamo"},
+	{Alpha2: "si", Alpha3: "sin", Numeric: "", Name: "Sinhala"},
+	{Alpha2: "", Alpha3: "sio", Numeric: "", Name: "Siouan languages"},
+	{Alpha2: "", Alpha3: "sit", Numeric: "", Name: "Sino-Tibetan languages"},
+	{Alpha2: "", Alpha3: "sla", Numeric: "", Name: "Slavic languages"},
+	{Alpha2: "sk", Alpha3: "slk", Numeric: "", Name: "Slovak"},
+	{Alpha2: "sl", Alpha3: "slv", Numeric: "", Name: "Slovenian"},
+	{Alpha2: "", Alpha3: "sma", Numeric: "", Name: "Southern Sami"},
+	{Alpha2: "se", Alpha3: "sme", Numeric: "", Name: "Northern Sami"},
+	{Alpha2: "", Alpha3: "smi", Numeric: "", Name: "Sami languages"},
+	{Alpha2: "", Alpha3: "smj", Numeric: "", Name: "Lule Sami"},
+	{Alpha2: "", Alpha3: "smn", Numeric: "", Name: "Inari Sami"},
+	{Alpha2: "sm", Alpha3: "smo", Numeric: "", Name: "Samoan"},
+	{Alpha2: "", Alpha3: "sms", Numeric: "", Name: "Skolt Sami"},
+	{Alpha2: "sn", Alpha3: "sna", Numeric: "", Name: "Shona"},
+	{Alpha2: "sd", Alpha3: "snd", Numeric: "", Name: "Sindhi"},
+	{Alpha2: "", Alpha3: "snk", Numeric: "", Name: "Soninke"},
+	{Alpha2: "", Alpha3: "sog", Numeric: "", Name: "Sogdian"},
+	{Alpha2: "so", Alpha3: "som", Numeric: "", Name: "Somali"},
+	{Alpha2: "", Alpha3: "son", Numeric: "", Name: "Songhai languages"},
+	{Alpha2: "st", Alpha3: "sot", Numeric: "", Name: "Sotho, Southern"},
+	{Alpha2: "es", Alpha3: "spa", Numeric: "", Name: "Spanish"},
+	{Alpha2: "sq", Alpha3: "sqi", Numeric: "", Name: "Albanian"},
+	{Alpha2: "sc", Alpha3: "srd", Numeric: "", Name: "Sardinian"},
+	{Alpha2: "", Alpha3: "srn", Numeric: "", Name: "Sranan Tongo"},
+	{Alpha2: "sr", Alpha3: "srp", Numeric: "", Name: "Serbian"},
+	{Alpha2: "", Alpha3: "srr", Numeric: "", Name: "Serer"},
+	{Alpha2: "", Alpha3: "ssa", Numeric: "", Name: "Nilo-Saharan languages"},
+	{Alpha2: "ss", Alpha3: "ssw", Numeric: "", Name: "Swati"},
+	{Alpha2: "", Alpha3: "suk", Numeric: "", Name: "Sukuma"},
+	{Alpha2: "su", Alpha3: "sun", Numeric: "", Name: "Sundanese"},
+	{Alpha2: "", Alpha3: "sus", Numeric: "", Name: "Susu"},
+	{Alpha2: "", Alpha3: "sux", Numeric: "", Name: "Sumerian"},
+	{Alpha2: "sw", Alpha3: "swa", Numeric: "", Name: "Swahili"},
+	{Alpha2: "sv", Alpha3: "swe", Numeric: "", Name: "Swedish"},
+	{Alpha2: "", Alpha3: "syc", Numeric: "", Name: "Classical Syriac"},
+	{Alpha2: "", Alpha3: "syr", Numeric: "", Name: "Syriac"},
+	{Alpha2: "ty", Alpha3: "tah", Numeric: "", Name: "Tahitian"},
+	{Alpha2: "", Alpha3: "tai", Numeric: "", Name: "Tai languages"},
+	{Alpha2: "ta", Alpha3: "tam", Numeric: "", Name: "Tamil"},
+	{Alpha2: "tt", Alpha3: "tat", Numeric: "", Name: "Tatar"},
+	{Alpha2: "te", Alpha3: "tel", Numeric: "", Name: "Telugu"},
+	{Alpha2: "", Alpha3: "tem", Numeric: "", Name: "Timne"},
+	{Alpha2: "", Alpha3: "ter", Numeric: "", Name: "Tereno"},
+	{Alpha2: "", Alpha3: "tet", Numeric: "", Name: "Tetum"},
+	{Alpha2: "tg", Alpha3: "tgk", Numeric: "", Name: "Tajik"},
+	{Alpha2: "tl", Alpha3: "tgl", Numeric: "", Name: "Tagalog"},
+	{Alpha2: "th", Alpha3: "tha", Numeric: "", Name: "Thai"},
+	{Alpha2: "", Alpha3: "tig", Numeric: "", Name: "Tigre"},
+	{Alpha2: "ti", Alpha3: "tir", Numeric: "", Name: "Tigrinya"},
+	{Alpha2: "", Alpha3: "tiv", Numeric: "", Name: "Tiv"},
+	{Alpha2: "", Alpha3: "tkl", Numeric: "", Name: "Tokelau"},
+	{Alpha2: "", Alpha3: "tlh", Numeric: "", Name: "Klingon"},
+	{Alpha2: "", Alpha3: "tli", Numeric: "", Name: "Tlingit"},
+	{Alpha2: "", Alpha3: "tmh", Numeric: "", Name: "Tamashek"},
+	{Alpha2: "", Alpha3: "tog", Numeric: "", Name: "Tonga (Nyasa)"},
+	{Alpha2: "to", Alpha3: "ton", Numeric: "", Name: "Tonga (Tonga Islands)"},
+	{Alpha2: "", Alpha3: "tpi", Numeric: "", Name: "Tok Pisin"},
+	{Alpha2: "", Alpha3: "tsi", Numeric: "", Name: "Tsimshian"},
+	{Alpha2: "tn", Alpha3: "tsn", Numeric: "", Name: "Tswana"},
+	{Alpha2: "ts", Alpha3: "tso", Numeric: "", Name: "Tsonga"},
+	{Alpha2: "tk", Alpha3: "tuk", Numeric: "", Name: "Turkmen"},
+	{Alpha2: "", Alpha3: "tum", Numeric: "", Name: "Tumbuka"},
+	{Alpha2: "", Alpha3: "tup", Numeric: "", Name: "Tupi languages"},
+	{Alpha2: "tr", Alpha3: "tur", Numeric: "", Name: "Turkish"},
+	{Alpha2: "", Alpha3: "tut", Numeric: "", Name: "Altaic languages"},
+	{Alpha2: "", Alpha3: "tvl", Numeric: "", Name: "Tuvalu"},
+	{Alpha2: "tw", Alpha3: "twi", Numeric: "", Name: "Twi"},
+	{Alpha2: "", Alpha3: "tyv", Numeric: "", Name: "Tuvinian"},
+	{Alpha2: "", Alpha3: "udm", Numeric: "", Name: "Udmurt"},
+	{Alpha2: "", Alpha3: "uga", Numeric: "", Name: "Ugaritic"},
+	{Alpha2: "ug", Alpha3: "uig", Numeric: "", Name: "Uighur"},
+	{Alpha2: "uk", Alpha3: "ukr", Numeric: "", Name: "Ukrainian"},
+	{Alpha2: "", Alpha3: "umb", Numeric: "", Name: "Umbundu"},
+	{Alpha2: "", Alpha3: "und", Numeric: "", Name: "Undetermined"},
+	{Alpha2: "ur", Alpha3: "urd", Numeric: "", Name: "Urdu"},
+	{Alpha2: "uz", Alpha3: "uzb", Numeric: "", Name: "Uzbek"},
+	{Alpha2: "", Alpha3: "vai", Numeric: "", Name: "Vai"},
+	{Alpha2: "ve", Alpha3: "ven", Numeric: "", Name: "Venda"},
+	{Alpha2: "vi", Alpha3: "vie", Numeric: "", Name: "Vietnamese"},
+	{Alpha2: "vo", Alpha3: "vol", Numeric: "", Name: "Volapük"},
+	{Alpha2: "", Alpha3: "vot", Numeric: "", Name: "Votic"},
+	{Alpha2: "", Alpha3: "wak", Numeric: "", Name: "Wakashan languages"},
+	{Alpha2: "", Alpha3: "wal", Numeric: "", Name: "Walamo"},
+	{Alpha2: "", Alpha3: "war", Numeric: "", Name: "Waray"},
+	{Alpha2: "", Alpha3: "was", Numeric: "", Name: "Washo"},
+	{Alpha2: "", Alpha3: "wen", Numeric: "", Name: "Sorbian languages"},
+	{Alpha2: "wa", Alpha3: "wln", Numeric: "", Name: "Walloon"},
+	{Alpha2: "wo", Alpha3: "wol", Numeric: "", Name: "Wolof"},
+	{Alpha2: "", Alpha3: "xal", Numeric: "", Name: "Kalmyk"},
+	{Alpha2: "xh", Alpha3: "xho", Numeric: "", Name: "Xhosa"},
+	{Alpha2: "", Alpha3: "yao", Numeric: "", Name: "Yao"},
+	{Alpha2: "", Alpha3: "yap", Numeric: "", Name: "Yapese"},
+	{Alpha2: "yi", Alpha3: "yid", Numeric: "", Name: "Yiddish"},
+	{Alpha2: "yo", Alpha3: "yor", Numeric: "", Name: "Yoruba"},
+	{Alpha2: "", Alpha3: "ypk", Numeric: "", Name: "Yupik languages"},
+	{Alpha2: "", Alpha3: "zap", Numeric: "", Name: "Zapotec"},
+	{Alpha2: "", Alpha3: "zbl", Numeric: "", Name: "Blissymbols"},
+	{Alpha2: "", Alpha3: "zen", Numeric: "", Name: "Zenaga"},
+	{Alpha2: "", Alpha3: "zgh", Numeric: "", Name: "Standard Moroccan Tamazight"},
+	{Alpha2: "za", Alpha3: "zha", Numeric: "", Name: "Zhuang"},
+	{Alpha2: "zh", Alpha3: "zho", Numeric: "", Name: "Chinese"},
+	{Alpha2: "", Alpha3: "znd", Numeric: "", Name: "Zande languages"},
+	{Alpha2: "zu", Alpha3: "zul", Numeric: "", Name: "Zulu"},
+	{Alpha2: "", Alpha3: "zun", Numeric: "", Name: "Zuni"},
+	{Alpha2: "", Alpha3: "zxx", Numeric: "", Name: "No linguistic content"},
+	{Alpha2: "", Alpha3: "zza", Numeric: "", Name: "Zaza"},
+}
@@ -0,0 +1,120 @@
+// Package iso provides compiled-in snapshots of ISO 3166-1 (countries), ISO 4217 (currencies)
+// and ISO 639 (languages) standards that can be used as idmapper.SourceReader.
+package iso
+
+//go:generate go run ../cmd/isogen -dir /usr/share/iso-codes/json -out data.go
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/danielkraic/idmapper/idmapper"
+)
+
+// Entry is single record of ISO standard. Codes not defined for entry are empty
+type Entry struct {
+	Alpha2  string
+	Alpha3  string
+	Numeric string
+	Name    string
+}
+
+// Dataset identifies embedded ISO standard
+type Dataset string
+
+const (
+	// Countries is ISO 3166-1 dataset
+	Countries Dataset = "country"
+	// Currencies is ISO 4217 dataset
+	Currencies Dataset = "currency"
+	// Languages is ISO 639 dataset
+	Languages Dataset = "language"
+)
+
+// Key identifies code of Entry used as ID
+type Key string
+
+const (
+	// Alpha2 is two-letter code (not defined for currencies)
+	Alpha2 Key = "alpha2"
+	// Alpha3 is three-letter code
+	Alpha3 Key = "alpha3"
+	// Numeric is three-digit code (not defined for languages)
+	Numeric Key = "numeric"
+)
+
+// Entries returns all entries of dataset
+func Entries(dataset Dataset) ([]Entry, error) {
+	switch dataset {
+	case Countries:
+		return countries, nil
+	case Currencies:
+		return currencies, nil
+	case Languages:
+		return languages, nil
+	default:
+		return nil, fmt.Errorf("unknown ISO dataset '%s'", dataset)
+	}
+}
+
+// DefaultKey returns key commonly used as ID for dataset: Alpha3 for currencies, Alpha2 otherwise
+func DefaultKey(dataset Dataset) Key {
+	if dataset == Currencies {
+		return Alpha3
+	}
+	return Alpha2
+}
+
+// Source is idmapper.SourceReader that reads values from embedded ISO dataset
+type Source struct {
+	entries []Entry
+	key     Key
+}
+
+// NewSource creates Source for dataset using given key as ID. DefaultKey is used if key is empty
+func NewSource(dataset Dataset, key Key) (*Source, error) {
+	entries, err := Entries(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == "" {
+		key = DefaultKey(dataset)
+	}
+
+	switch key {
+	case Alpha2, Alpha3, Numeric:
+	default:
+		return nil, fmt.Errorf("unknown ISO key '%s'", key)
+	}
+
+	return &Source{
+		entries: entries,
+		key:     key,
+	}, nil
+}
+
+// Read returns values of dataset. Alphabetic codes are lowercased, entries without code are skipped
+func (source *Source) Read() (idmapper.ValuesMap, error) {
+	result := make(idmapper.ValuesMap, len(source.entries))
+
+	for _, entry := range source.entries {
+		var id string
+		switch source.key {
+		case Alpha2:
+			id = strings.ToLower(entry.Alpha2)
+		case Alpha3:
+			id = strings.ToLower(entry.Alpha3)
+		case Numeric:
+			id = entry.Numeric
+		}
+
+		if id == "" {
+			continue
+		}
+
+		result[id] = entry.Name
+	}
+
+	return result, nil
+}
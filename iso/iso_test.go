@@ -0,0 +1,71 @@
+package iso_test
+
+import (
+	"testing"
+
+	"github.com/danielkraic/idmapper/idmapper"
+	"github.com/danielkraic/idmapper/iso"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceDefaultKeys(t *testing.T) {
+	tests := []struct {
+		dataset iso.Dataset
+		id      string
+		name    string
+	}{
+		{iso.Countries, "sk", "Slovakia"},
+		{iso.Currencies, "eur", "Euro"},
+		{iso.Languages, "sk", "Slovak"},
+	}
+
+	for _, test := range tests {
+		source, err := iso.NewSource(test.dataset, "")
+		assert.Nil(t, err)
+
+		idMapper, err := idmapper.NewIDMapper(source)
+		assert.Nil(t, err)
+
+		name, found := idMapper.Get(test.id)
+		assert.True(t, found)
+		assert.Equal(t, test.name, name)
+	}
+}
+
+func TestSourceKeys(t *testing.T) {
+	tests := []struct {
+		key  iso.Key
+		id   string
+		name string
+	}{
+		{iso.Alpha2, "sk", "Slovakia"},
+		{iso.Alpha3, "svk", "Slovakia"},
+		{iso.Numeric, "703", "Slovakia"},
+	}
+
+	for _, test := range tests {
+		source, err := iso.NewSource(iso.Countries, test.key)
+		assert.Nil(t, err)
+
+		values, err := source.Read()
+		assert.Nil(t, err)
+		assert.Equal(t, test.name, values[test.id])
+	}
+}
+
+func TestSourceSkipsMissingCodes(t *testing.T) {
+	source, err := iso.NewSource(iso.Currencies, iso.Alpha2)
+	assert.Nil(t, err)
+
+	values, err := source.Read()
+	assert.Nil(t, err)
+	assert.Empty(t, values)
+}
+
+func TestSourceInvalid(t *testing.T) {
+	_, err := iso.NewSource("unknown", "")
+	assert.EqualError(t, err, "unknown ISO dataset 'unknown'")
+
+	_, err = iso.NewSource(iso.Countries, "unknown")
+	assert.EqualError(t, err, "unknown ISO key 'unknown'")
+}
@@ -0,0 +1,326 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is Schedule defined by cron expression
+type CronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	location                              *time.Location
+}
+
+type cronField struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// day of week 7 is alias for Sunday (0)
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	cronMacros = map[string]string{
+		"@yearly":   "0 0 0 1 1 *",
+		"@annually": "0 0 0 1 1 *",
+		"@monthly":  "0 0 0 1 * *",
+		"@weekly":   "0 0 0 * * 0",
+		"@daily":    "0 0 0 * * *",
+		"@midnight": "0 0 0 * * *",
+		"@hourly":   "0 0 * * * *",
+	}
+)
+
+// starBit marks field defined by '*' or '?', used to evaluate day of month and day of week as in standard cron
+const starBit = 1 << 63
+
+// ParseCron parses cron expression. Supported formats are:
+//
+//	standard 5 fields:       minute hour day-of-month month day-of-week
+//	6 fields with seconds:   second minute hour day-of-month month day-of-week
+//	macros:                  @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly
+//	fixed interval:          @every <duration>, e.g. @every 1h30m
+//
+// Fields support '*', '?', lists (1,5), ranges (1-5), steps (*/15, 0-30/10) and names of months and days of week (JAN, MON).
+// Expression may be prefixed by time zone, e.g. 'CRON_TZ=Europe/Bratislava 5 6 * * MON-FRI'. Local time zone is used by default.
+func ParseCron(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	location := time.Local
+
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		parts := strings.SplitN(spec, " ", 2)
+		name := parts[0][strings.Index(parts[0], "=")+1:]
+
+		var err error
+		location, err = time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': invalid time zone: %s", spec, err)
+		}
+
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("invalid cron expression '%s': missing fields", spec)
+		}
+		spec = strings.TrimSpace(parts[1])
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		duration, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid cron expression '%s': invalid duration", spec)
+		}
+		return Every(duration), nil
+	}
+
+	expression := spec
+	if macro, found := cronMacros[strings.ToLower(spec)]; found {
+		expression = macro
+	}
+
+	fields := strings.Fields(expression)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 or 6 fields, found %d", spec, len(fields))
+	}
+
+	schedule := &CronSchedule{location: location}
+	var err error
+	for i, target := range []struct {
+		field *cronField
+		bits  *uint64
+	}{
+		{&cronSecond, &schedule.second},
+		{&cronMinute, &schedule.minute},
+		{&cronHour, &schedule.hour},
+		{&cronDom, &schedule.dom},
+		{&cronMonth, &schedule.month},
+		{&cronDow, &schedule.dow},
+	} {
+		*target.bits, err = target.field.parse(fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': %s", spec, err)
+		}
+	}
+
+	// Sunday may be written as 0 or 7
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+
+	return schedule, nil
+}
+
+func (field cronField) parse(expression string) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(expression, ",") {
+		partBits, err := field.parsePart(part)
+		if err != nil {
+			return 0, err
+		}
+		bits |= partBits
+	}
+
+	return bits, nil
+}
+
+func (field cronField) parsePart(part string) (uint64, error) {
+	var start, end, step uint = 0, 0, 1
+	var bits uint64
+
+	rangeAndStep := strings.SplitN(part, "/", 2)
+
+	switch rangeAndStep[0] {
+	case "*", "?":
+		start, end = field.min, field.max
+		bits = starBit
+	default:
+		bounds := strings.SplitN(rangeAndStep[0], "-", 2)
+
+		var err error
+		start, err = field.parseValue(bounds[0])
+		if err != nil {
+			return 0, err
+		}
+
+		end = start
+		if len(bounds) == 2 {
+			end, err = field.parseValue(bounds[1])
+			if err != nil {
+				return 0, err
+			}
+		} else if len(rangeAndStep) == 2 {
+			// 'N/step' means range from N to maximum
+			end = field.max
+		}
+	}
+
+	if len(rangeAndStep) == 2 {
+		value, err := strconv.ParseUint(rangeAndStep[1], 10, 8)
+		if err != nil || value == 0 {
+			return 0, fmt.Errorf("invalid step '%s' of %s", rangeAndStep[1], field.name)
+		}
+		step = uint(value)
+		// '*/step' does not behave as '*'
+		bits = 0
+	}
+
+	if start > end {
+		return 0, fmt.Errorf("invalid range '%s' of %s", part, field.name)
+	}
+
+	for value := start; value <= end; value += step {
+		bits |= 1 << value
+	}
+
+	return bits, nil
+}
+
+func (field cronField) parseValue(value string) (uint, error) {
+	if number, found := field.names[strings.ToLower(value)]; found {
+		return number, nil
+	}
+
+	number, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s' of %s", value, field.name)
+	}
+
+	if uint(number) < field.min || uint(number) > field.max {
+		return 0, fmt.Errorf("value %d of %s out of range %d-%d", number, field.name, field.min, field.max)
+	}
+
+	return uint(number), nil
+}
+
+// Next returns first time matching cron expression after given time. Returns zero time if there is no such time within 5 years.
+// Times skipped when clock is turned forward (daylight saving time starts) are run right after the transition.
+// Times repeated when clock is turned back are run only once, unless hour is '*'
+func (schedule *CronSchedule) Next(t time.Time) time.Time {
+	originalLocation := t.Location()
+	t = t.In(schedule.location).Add(time.Second - time.Duration(t.Nanosecond()))
+	limit := t.AddDate(5, 0, 0)
+
+	// skipped is true if time matching cron expression was skipped by turning clock forward to t
+	skipped := false
+	for t.Before(limit) {
+		if skipped {
+			return t.In(originalLocation)
+		}
+
+		if schedule.month&(1<<uint(t.Month())) == 0 {
+			t, skipped = schedule.startOfHour(t.Year(), t.Month()+1, 1, 0)
+			continue
+		}
+
+		if !schedule.dayMatches(t) {
+			t, skipped = schedule.startOfHour(t.Year(), t.Month(), t.Day()+1, 0)
+			continue
+		}
+
+		if schedule.hour&(1<<uint(t.Hour())) == 0 {
+			t, skipped = schedule.startOfHour(t.Year(), t.Month(), t.Day(), t.Hour()+1)
+			continue
+		}
+
+		if schedule.minute&(1<<uint(t.Minute())) == 0 || (schedule.hour&starBit == 0 && !firstOccurrence(t).Equal(t)) {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+
+		if schedule.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+
+		return t.In(originalLocation)
+	}
+
+	return time.Time{}
+}
+
+// startOfHour returns beginning of given hour in location of schedule, values out of range are normalized as in time.Date.
+// Hour repeated after clock was turned back starts at its first occurrence. If the beginning of hour was skipped because
+// clock was turned forward, time of the transition is returned and skipped is true if any skipped minute matches schedule
+func (schedule *CronSchedule) startOfHour(year int, month time.Month, day int, hour int) (t time.Time, skipped bool) {
+	t = time.Date(year, month, day, hour, 0, 0, 0, schedule.location)
+	// wall clock times are compared as times in UTC
+	requested := time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	if wallClock(t).Equal(requested) {
+		return firstOccurrence(t), false
+	}
+
+	// transition is between instants of requested wall clock time with offsets before and after it
+	_, offset := t.Zone()
+	earliest := requested.Add(-time.Duration(offset) * time.Second).In(schedule.location)
+	_, otherOffset := earliest.Zone()
+	latest := requested.Add(-time.Duration(otherOffset) * time.Second).In(schedule.location)
+	if latest.Before(earliest) {
+		earliest, latest = latest, earliest
+	}
+	_, offsetAfter := latest.Zone()
+	for latest.Sub(earliest) > time.Second {
+		middle := earliest.Add(latest.Sub(earliest) / 2)
+		if _, offset := middle.Zone(); offset == offsetAfter {
+			latest = middle
+		} else {
+			earliest = middle
+		}
+	}
+	t = latest.In(schedule.location)
+
+	for minute := requested; minute.Before(wallClock(t)); minute = minute.Add(time.Minute) {
+		if schedule.month&(1<<uint(minute.Month())) != 0 && schedule.dayMatches(minute) &&
+			schedule.hour&(1<<uint(minute.Hour())) != 0 && schedule.minute&(1<<uint(minute.Minute())) != 0 {
+			return t, true
+		}
+	}
+	return t, false
+}
+
+// wallClock returns wall clock time of t as time in UTC
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// firstOccurrence returns first time with the same wall clock time as t. It differs from t if t is in period repeated
+// after clock was turned back within previous 3 hours
+func firstOccurrence(t time.Time) time.Time {
+	_, offset := t.Zone()
+	_, previousOffset := t.Add(-3 * time.Hour).Zone()
+	if previousOffset <= offset {
+		return t
+	}
+
+	earlier := t.Add(-time.Duration(previousOffset-offset) * time.Second)
+	if _, earlierOffset := earlier.Zone(); earlierOffset == previousOffset {
+		return earlier
+	}
+	return t
+}
+
+// dayMatches evaluates day of month and day of week. If both are restricted, day matches if any of them matches
+func (schedule *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := schedule.dom&(1<<uint(t.Day())) != 0
+	dowMatch := schedule.dow&(1<<uint(t.Weekday())) != 0
+
+	if schedule.dom&starBit != 0 || schedule.dow&starBit != 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
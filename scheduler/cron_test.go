@@ -0,0 +1,132 @@
+package scheduler_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/danielkraic/idmapper/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronNext(t *testing.T) {
+	utc := func(value string) time.Time {
+		result, err := time.Parse("2006-01-02 15:04:05", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	tests := []struct {
+		spec     string
+		from     string
+		expected string
+	}{
+		{"CRON_TZ=UTC * * * * *", "2019-09-10 10:00:00", "2019-09-10 10:01:00"},
+		{"CRON_TZ=UTC * * * * *", "2019-09-10 10:00:30", "2019-09-10 10:01:00"},
+		{"CRON_TZ=UTC */15 * * * * *", "2019-09-10 10:00:14", "2019-09-10 10:00:15"},
+		{"CRON_TZ=UTC 5 6 * * MON-FRI", "2019-09-10 10:00:00", "2019-09-11 06:05:00"},
+		{"CRON_TZ=UTC 5 6 * * mon-fri", "2019-09-13 06:05:00", "2019-09-16 06:05:00"},
+		{"CRON_TZ=UTC 0 0 1 * *", "2019-12-15 00:00:00", "2020-01-01 00:00:00"},
+		{"CRON_TZ=UTC 0 12 29 2 *", "2019-03-01 00:00:00", "2020-02-29 12:00:00"},
+		{"CRON_TZ=UTC 0 0 1,15 * 0", "2019-09-02 00:00:00", "2019-09-08 00:00:00"},
+		{"CRON_TZ=UTC 0 0 * * 7", "2019-09-02 00:00:00", "2019-09-08 00:00:00"},
+		{"CRON_TZ=UTC 0 0 */10 * *", "2019-09-02 00:00:00", "2019-09-11 00:00:00"},
+		{"CRON_TZ=UTC 30 8-18/4 * JAN,JUL ?", "2019-06-30 23:00:00", "2019-07-01 08:30:00"},
+		{"CRON_TZ=UTC @daily", "2019-09-10 10:00:00", "2019-09-11 00:00:00"},
+		{"CRON_TZ=UTC @hourly", "2019-09-10 10:00:00", "2019-09-10 11:00:00"},
+		{"CRON_TZ=UTC @weekly", "2019-09-10 10:00:00", "2019-09-15 00:00:00"},
+		{"CRON_TZ=UTC @monthly", "2019-09-10 10:00:00", "2019-10-01 00:00:00"},
+		{"CRON_TZ=UTC @yearly", "2019-09-10 10:00:00", "2020-01-01 00:00:00"},
+		{"@every 90m", "2019-09-10 10:00:00", "2019-09-10 11:30:00"},
+		// 06:05 in Bratislava (UTC+2 in summer) is 04:05 UTC
+		{"CRON_TZ=Europe/Bratislava 5 6 * * *", "2019-09-10 10:00:00", "2019-09-11 04:05:00"},
+		{"TZ=Europe/Bratislava 5 6 * * *", "2019-12-10 10:00:00", "2019-12-11 05:05:00"},
+		// clock is turned forward from 02:00 CET (01:00 UTC) to 03:00 CEST, skipped time runs right after transition
+		{"CRON_TZ=Europe/Bratislava 30 2 * * *", "2020-03-28 12:00:00", "2020-03-29 01:00:00"},
+		{"CRON_TZ=Europe/Bratislava 30 2 * * *", "2020-03-29 01:00:00", "2020-03-30 00:30:00"},
+		{"CRON_TZ=Europe/Bratislava 30 3 * * *", "2020-03-28 12:00:00", "2020-03-29 01:30:00"},
+		{"CRON_TZ=Europe/Bratislava 30 * * * *", "2020-03-29 00:40:00", "2020-03-29 01:30:00"},
+		{"CRON_TZ=America/New_York 30 2 * * *", "2020-03-07 12:00:00", "2020-03-08 07:00:00"},
+		// clock is turned back from 03:00 CEST (01:00 UTC) to 02:00 CET, repeated time runs once unless hour is '*'
+		{"CRON_TZ=Europe/Bratislava 30 2 * * *", "2020-10-24 12:00:00", "2020-10-25 00:30:00"},
+		{"CRON_TZ=Europe/Bratislava 30 2 * * *", "2020-10-25 00:30:00", "2020-10-26 01:30:00"},
+		{"CRON_TZ=Europe/Bratislava 30 * * * *", "2020-10-25 00:30:00", "2020-10-25 01:30:00"},
+		{"CRON_TZ=America/New_York 30 1 * * *", "2020-10-31 12:00:00", "2020-11-01 05:30:00"},
+		{"CRON_TZ=America/New_York 30 1 * * *", "2020-11-01 05:30:00", "2020-11-02 06:30:00"},
+	}
+
+	for _, test := range tests {
+		schedule, err := scheduler.ParseCron(test.spec)
+		if !assert.Nil(t, err, test.spec) {
+			continue
+		}
+
+		next := schedule.Next(utc(test.from))
+		assert.Equal(t, utc(test.expected), next.UTC(), test.spec)
+		assert.Equal(t, time.UTC, next.Location(), test.spec)
+	}
+}
+
+func TestCronNextNever(t *testing.T) {
+	schedule, err := scheduler.ParseCron("0 0 30 2 *")
+	assert.Nil(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestCronInvalid(t *testing.T) {
+	tests := []struct {
+		spec string
+		err  string
+	}{
+		{"* * * *", "invalid cron expression '* * * *': expected 5 or 6 fields, found 4"},
+		{"* * * * * * *", "invalid cron expression '* * * * * * *': expected 5 or 6 fields, found 7"},
+		{"60 * * * *", "invalid cron expression '60 * * * *': value 60 of minute out of range 0-59"},
+		{"* 24 * * *", "invalid cron expression '* 24 * * *': value 24 of hour out of range 0-23"},
+		{"* * 0 * *", "invalid cron expression '* * 0 * *': value 0 of day of month out of range 1-31"},
+		{"* * * foo *", "invalid cron expression '* * * foo *': invalid value 'foo' of month"},
+		{"* * * * 5-1", "invalid cron expression '* * * * 5-1': invalid range '5-1' of day of week"},
+		{"*/0 * * * *", "invalid cron expression '*/0 * * * *': invalid step '0' of minute"},
+		{"@every 0s", "invalid cron expression '@every 0s': invalid duration"},
+		{"CRON_TZ=Nowhere/City * * * * *", "invalid cron expression 'CRON_TZ=Nowhere/City * * * * *': invalid time zone: unknown time zone Nowhere/City"},
+		{"CRON_TZ=UTC", "invalid cron expression 'CRON_TZ=UTC': missing fields"},
+	}
+
+	for _, test := range tests {
+		_, err := scheduler.ParseCron(test.spec)
+		assert.EqualError(t, err, test.err)
+	}
+}
+
+func TestAddCron(t *testing.T) {
+	var counter1, counter2 int32 = 0, 0
+
+	jobScheduler, fakeClock := newTestScheduler()
+	err := jobScheduler.AddCron(scheduler.JobFunc(func() {
+		atomic.AddInt32(&counter1, 1)
+	}), "@every 100ms")
+	assert.Nil(t, err)
+
+	// every minute at 30th second
+	err = jobScheduler.AddCron(scheduler.JobFunc(func() {
+		atomic.AddInt32(&counter2, 1)
+	}), "CRON_TZ=UTC 30 * * * * *")
+	assert.Nil(t, err)
+
+	err = jobScheduler.AddCron(scheduler.JobFunc(func() {}), "invalid")
+	assert.EqualError(t, err, "unable to add item to scheduler: invalid cron expression 'invalid': expected 5 or 6 fields, found 1")
+
+	jobScheduler.Start()
+
+	advance(t, jobScheduler, fakeClock, 350*time.Millisecond, 50*time.Millisecond)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&counter1))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&counter2))
+
+	assert.Nil(t, jobScheduler.Remove("job-1"))
+	advance(t, jobScheduler, fakeClock, 2*time.Minute, 10*time.Second)
+	jobScheduler.Stop()
+
+	// runs at 00:00:30 and 00:01:30
+	assert.Equal(t, int32(2), atomic.LoadInt32(&counter2))
+}
@@ -0,0 +1,128 @@
+package scheduler
+
+import "time"
+
+// Option configures job added to scheduler
+type Option func(*item)
+
+// WithName sets name of job, it must be unique within scheduler. Jobs are named job-1, job-2, ... by default
+func WithName(name string) Option {
+	return func(item *item) {
+		item.status.Name = name
+	}
+}
+
+// WithTimeout sets maximal duration of single job run. Context passed to JobWithContext is cancelled after timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(item *item) {
+		item.timeout = timeout
+	}
+}
+
+// WithRetry sets policy of retrying failed runs of JobWithContext
+func WithRetry(policy RetryPolicy) Option {
+	return func(item *item) {
+		item.retry = policy
+	}
+}
+
+// WithJitter delays each regular run of job by random duration up to jitter, so jobs of multiple
+// instances started at the same time do not run at the same instants
+func WithJitter(jitter time.Duration) Option {
+	return func(item *item) {
+		item.jitter = jitter
+	}
+}
+
+// WithInitialDelay postpones first regular run of job, it is planned according to schedule after scheduler start plus delay
+func WithInitialDelay(delay time.Duration) Option {
+	return func(item *item) {
+		item.initialDelay = delay
+	}
+}
+
+// WithRunOnStart runs job immediately (or after initial delay) when scheduler is started or job is added to running scheduler.
+// It is ignored if start time of last successful run of job is loaded from Scheduler.Store
+func WithRunOnStart() Option {
+	return func(item *item) {
+		item.runOnStart = true
+	}
+}
+
+// WithOverlap sets policy applied when job should be run while its previous run has not finished yet. OverlapSkip is used by default
+func WithOverlap(policy OverlapPolicy) Option {
+	return func(item *item) {
+		item.overlap = policy
+	}
+}
+
+// WithBlackouts postpones regular runs and retries of job planned during any of blackouts until the blackout ends
+func WithBlackouts(blackouts ...Window) Option {
+	return func(item *item) {
+		item.blackouts = append(item.blackouts, blackouts...)
+	}
+}
+
+// WithWindows allows regular runs and retries of job only during windows, runs planned outside of them are postponed
+// until next window opens
+func WithWindows(windows ...Window) Option {
+	return func(item *item) {
+		item.windows = append(item.windows, windows...)
+	}
+}
+
+// WithCatchUp sets policy applied to runs of job missed while scheduler was not running. CatchUpOnce is used by default
+func WithCatchUp(policy CatchUpPolicy) Option {
+	return func(item *item) {
+		item.catchUp = policy
+	}
+}
+
+// OverlapPolicy defines handling of job run requested while previous run of job has not finished yet
+type OverlapPolicy string
+
+const (
+	// OverlapSkip skips new run
+	OverlapSkip OverlapPolicy = "skip"
+	// OverlapQueue runs job once more after current run finishes, multiple pending runs are merged into one
+	OverlapQueue OverlapPolicy = "queue"
+	// OverlapAllow runs job concurrently with its previous runs
+	OverlapAllow OverlapPolicy = "allow"
+)
+
+// RetryPolicy defines retrying of failed job runs. Failed run is retried after backoff, which doubles
+// after each consecutive failure up to MaxBackoff. Retries are not planned after next regular run of job.
+// Zero RetryPolicy disables retries
+type RetryPolicy struct {
+	// InitialBackoff is delay between failed run and first retry
+	InitialBackoff time.Duration
+	// MaxBackoff is maximal delay between retries, backoff is not limited if zero
+	MaxBackoff time.Duration
+}
+
+// backoff returns backoff following previous one, zero if retries are disabled
+func (policy RetryPolicy) backoff(previous time.Duration) time.Duration {
+	if policy.InitialBackoff <= 0 {
+		return 0
+	}
+
+	backoff := policy.InitialBackoff
+	if previous > 0 {
+		backoff = 2 * previous
+	}
+
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+
+	return backoff
+}
+
+// WithLastSuccess records start time of successful run of job executed outside of scheduler, e.g. initial load done
+// before scheduler is started. If it is later than start time loaded from Scheduler.Store, it is saved to store and
+// runs of job are planned according to it, so the run is not repeated by catch-up. It is ignored if Scheduler.Store is nil
+func WithLastSuccess(last time.Time) Option {
+	return func(item *item) {
+		item.lastRun = last
+	}
+}
@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Schedule describes when job is run
+type Schedule interface {
+	// Next returns next time of job run after given time
+	Next(time.Time) time.Time
+}
+
+// Every returns Schedule with fixed duration between runs. Schedule with non-positive duration never runs
+func Every(duration time.Duration) Schedule {
+	return interval(duration)
+}
+
+type interval time.Duration
+
+func (interval interval) Next(t time.Time) time.Time {
+	if interval <= 0 {
+		return time.Time{}
+	}
+	return t.Add(time.Duration(interval))
+}
+
+// AdaptiveSchedule is Schedule with fixed duration between runs, which is adapted to frequency of changes observed by job.
+// Duration is halved after each run that observed change and doubled after each run that observed no change,
+// within bounds of the schedule. May be shared between goroutines
+type AdaptiveSchedule struct {
+	min      time.Duration
+	max      time.Duration
+	interval time.Duration
+	mtx      sync.Mutex
+}
+
+// Adaptive returns AdaptiveSchedule with duration between runs bounded by min and max, starting with initial duration
+func Adaptive(min time.Duration, max time.Duration, initial time.Duration) (*AdaptiveSchedule, error) {
+	if min <= 0 || max < min {
+		return nil, fmt.Errorf("unable to create adaptive schedule: invalid bounds %s - %s", min, max)
+	}
+
+	schedule := &AdaptiveSchedule{min: min, max: max}
+	schedule.interval = schedule.bound(initial)
+	return schedule, nil
+}
+
+// Next returns time after given time by current duration between runs
+func (schedule *AdaptiveSchedule) Next(t time.Time) time.Time {
+	return t.Add(schedule.Interval())
+}
+
+// Interval returns current duration between runs
+func (schedule *AdaptiveSchedule) Interval() time.Duration {
+	schedule.mtx.Lock()
+	defer schedule.mtx.Unlock()
+	return schedule.interval
+}
+
+// Observe adapts duration between runs according to result of run, returns new duration.
+// Scheduler plans runs of job with changed duration after its schedule is set again by SetSchedule
+func (schedule *AdaptiveSchedule) Observe(changed bool) time.Duration {
+	schedule.mtx.Lock()
+	defer schedule.mtx.Unlock()
+
+	if changed {
+		schedule.interval = schedule.bound(schedule.interval / 2)
+	} else {
+		schedule.interval = schedule.bound(schedule.interval * 2)
+	}
+
+	return schedule.interval
+}
+
+func (schedule *AdaptiveSchedule) bound(interval time.Duration) time.Duration {
+	if interval < schedule.min {
+		return schedule.min
+	}
+	if interval > schedule.max {
+		return schedule.max
+	}
+	return interval
+}
@@ -0,0 +1,64 @@
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielkraic/idmapper/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery(t *testing.T) {
+	assert.Equal(t, testStart.Add(time.Minute), scheduler.Every(time.Minute).Next(testStart))
+	assert.True(t, scheduler.Every(0).Next(testStart).IsZero())
+}
+
+func TestAdaptive(t *testing.T) {
+	schedule, err := scheduler.Adaptive(time.Minute, time.Hour, 2*time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, schedule.Interval())
+	assert.Equal(t, testStart.Add(time.Hour), schedule.Next(testStart))
+
+	assert.Equal(t, 30*time.Minute, schedule.Observe(true))
+	assert.Equal(t, 15*time.Minute, schedule.Observe(true))
+	assert.Equal(t, 30*time.Minute, schedule.Observe(false))
+	for i := 0; i < 10; i++ {
+		schedule.Observe(true)
+	}
+	assert.Equal(t, time.Minute, schedule.Interval())
+
+	_, err = scheduler.Adaptive(0, time.Hour, time.Hour)
+	assert.NotNil(t, err)
+	_, err = scheduler.Adaptive(time.Hour, time.Minute, time.Hour)
+	assert.NotNil(t, err)
+}
+
+func TestAdaptiveJob(t *testing.T) {
+	schedule, err := scheduler.Adaptive(time.Minute, time.Hour, 10*time.Minute)
+	assert.Nil(t, err)
+
+	jobScheduler, fakeClock := newTestScheduler()
+	changed := true
+	err = jobScheduler.AddWithContext(scheduler.JobWithContextFunc(func(ctx context.Context) error {
+		if schedule.Interval() != schedule.Observe(changed) {
+			return jobScheduler.SetSchedule("job", schedule)
+		}
+		return nil
+	}), schedule, scheduler.WithName("job"))
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+
+	// runs at 10m and 15m shrink interval
+	advance(t, jobScheduler, fakeClock, 15*time.Minute, time.Minute)
+	assert.Equal(t, testStart.Add(17*time.Minute+30*time.Second), jobScheduler.Jobs()[0].NextRun)
+
+	// run at 17m30s grows interval
+	changed = false
+	advance(t, jobScheduler, fakeClock, 3*time.Minute, time.Minute)
+	next := jobScheduler.Jobs()[0].NextRun
+	jobScheduler.Stop()
+
+	assert.Equal(t, testStart.Add(18*time.Minute+5*time.Minute), next)
+}
@@ -1,9 +1,14 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/danielkraic/idmapper/clock"
 )
 
 // Job interface with Run method
@@ -19,18 +24,130 @@ func (jobFunc JobFunc) Run() {
 	jobFunc()
 }
 
-type item struct {
+// JobWithContext interface with context-aware Run method. Returned error marks run as failed
+type JobWithContext interface {
+	Run(ctx context.Context) error
+}
+
+// JobWithContextFunc is adapter to allow use ordinary function as JobWithContext
+type JobWithContextFunc func(ctx context.Context) error
+
+// Run runs job
+func (jobFunc JobWithContextFunc) Run(ctx context.Context) error {
+	return jobFunc(ctx)
+}
+
+// jobAdapter allows to use Job as JobWithContext
+type jobAdapter struct {
 	job Job
-	// duration between last call and next call of job
-	duration time.Duration
-	done     chan struct{}
+}
+
+func (adapter jobAdapter) Run(ctx context.Context) error {
+	adapter.job.Run()
+	return nil
+}
+
+// JobStatus is snapshot of job state
+type JobStatus struct {
+	Name string
+	// Running is true if job is being run or waits for free worker
+	Running bool
+	// Paused is true if regular runs of job are paused
+	Paused bool
+	// LastStart is start time of last run, zero if job was not run yet
+	LastStart time.Time
+	// LastFinish is finish time of last finished run
+	LastFinish time.Time
+	// LastDuration is duration of last finished run
+	LastDuration time.Duration
+	// LastError is error returned by last finished run, empty if run was successful
+	LastError string
+	// Successes is count of successful runs
+	Successes uint64
+	// Failures is count of failed runs
+	Failures uint64
+	// NextRun is time of next planned run, zero if scheduler is not running or no run is planned
+	NextRun time.Time
+}
+
+// RunError is error returned by run of job started by RunNowWait or ForceRunNowWait
+type RunError struct {
+	Name string
+	Err  error
+}
+
+func (err *RunError) Error() string {
+	return fmt.Sprintf("run of job %s failed: %s", err.Name, err.Err)
+}
+
+type item struct {
+	job      JobWithContext
+	schedule Schedule
+	clock    clock.Clock
+	timeout  time.Duration
+	retry    RetryPolicy
+	overlap  OverlapPolicy
+	// jitter is maximal random delay added to each regular run
+	jitter       time.Duration
+	initialDelay time.Duration
+	runOnStart   bool
+	// blackouts are windows during which regular runs are postponed until blackout ends
+	blackouts []Window
+	// windows are windows during which regular runs are allowed, other runs are postponed until next window opens
+	windows []Window
+	// catchUp is policy applied to runs missed while scheduler was not running
+	catchUp CatchUpPolicy
+	// store persists start time of last successful run, nil if state is not persisted
+	store StateStore
+	// lastRun is start time of last successful run executed outside of scheduler
+	lastRun time.Time
+	done    chan struct{}
+	// wake notifies running job about change of its schedule or paused state
+	wake chan struct{}
+	// finished notifies running job that its run finished
+	finished chan struct{}
+
+	mtx    sync.Mutex
+	status JobStatus
+	// workers limits number of concurrently executed runs of all jobs, nil if not limited
+	workers chan struct{}
+	// ctx is parent context of runs, it is cancelled when scheduler is stopped
+	ctx context.Context
+	// inflight counts runs of all jobs that have not finished yet
+	inflight *sync.WaitGroup
+	// active is true if job is started by scheduler
+	active bool
+	// runs is number of dispatched runs that have not finished yet
+	runs int
+	// queued is true if another run should be executed after current one finishes
+	queued bool
+	// waiters receive result of queued run
+	waiters []chan error
+	// failed is true if last finished run failed
+	failed bool
 }
 
 // Scheduler to run periodic jobs
 type Scheduler struct {
-	items     []item
+	// Workers is maximal number of concurrently executed runs of all jobs, not limited if zero.
+	// Runs over limit wait for free worker. It must be set before scheduler is started
+	Workers int
+	// Clock is source of time used to plan runs of jobs, real clock is used if nil. It must be set before jobs are added
+	Clock clock.Clock
+	// Store persists start times of last successful runs of jobs. When scheduler is started, runs of jobs are planned
+	// according to them and missed runs are handled according to catch-up policies of jobs (see WithCatchUp).
+	// State is not persisted if nil. It must be set before jobs are added
+	Store StateStore
+
+	items   []*item
+	workers chan struct{}
+	// ctx is parent context of runs, it is cancelled by Stop
+	ctx       context.Context
+	cancel    context.CancelFunc
+	inflight  sync.WaitGroup
 	isRunning int32
 	done      chan struct{}
+	mtx       sync.Mutex
 }
 
 // IsRunning return true it scheduler is already running
@@ -46,65 +163,590 @@ func (scheduler *Scheduler) setIsRunning(isRunning bool) {
 	}
 }
 
-// Add adds job to scheduler
-func (scheduler *Scheduler) Add(job Job, duration time.Duration) error {
-	if scheduler.IsRunning() {
-		return fmt.Errorf("unable to add item to scheduler: scheduler is already running")
+// Add adds job to scheduler, job is run repeatedly with given duration between runs
+func (scheduler *Scheduler) Add(job Job, duration time.Duration, options ...Option) error {
+	if duration <= 0 {
+		return fmt.Errorf("unable to add item to scheduler: duration must be positive")
+	}
+
+	return scheduler.AddSchedule(job, Every(duration), options...)
+}
+
+// AddFunc adds function to scheduler
+func (scheduler *Scheduler) AddFunc(fn func(), duration time.Duration, options ...Option) error {
+	return scheduler.Add(JobFunc(fn), duration, options...)
+}
+
+// AddCron adds job to scheduler, job is run at times defined by cron expression (see ParseCron)
+func (scheduler *Scheduler) AddCron(job Job, spec string, options ...Option) error {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return fmt.Errorf("unable to add item to scheduler: %s", err)
 	}
 
-	scheduler.items = append(scheduler.items, item{
+	return scheduler.AddSchedule(job, schedule, options...)
+}
+
+// AddSchedule adds job to scheduler, job is run at times defined by schedule
+func (scheduler *Scheduler) AddSchedule(job Job, schedule Schedule, options ...Option) error {
+	return scheduler.AddWithContext(jobAdapter{job: job}, schedule, options...)
+}
+
+// AddWithContext adds context-aware job to scheduler, job is run at times defined by schedule.
+// Failed runs may be retried according to RetryPolicy set by WithRetry.
+// Job added to running scheduler is started immediately
+func (scheduler *Scheduler) AddWithContext(job JobWithContext, schedule Schedule, options ...Option) error {
+	scheduler.mtx.Lock()
+	defer scheduler.mtx.Unlock()
+
+	item := &item{
 		job:      job,
-		duration: duration,
+		schedule: schedule,
+		clock:    scheduler.Clock,
+		store:    scheduler.Store,
 		done:     make(chan struct{}),
+		wake:     make(chan struct{}, 1),
+		finished: make(chan struct{}, 1),
+	}
+	if item.clock == nil {
+		item.clock = clock.Real
+	}
+	item.status.Name = fmt.Sprintf("job-%d", len(scheduler.items)+1)
+
+	for _, option := range options {
+		option(item)
+	}
+
+	switch item.overlap {
+	case "":
+		item.overlap = OverlapSkip
+	case OverlapSkip, OverlapQueue, OverlapAllow:
+	default:
+		return fmt.Errorf("unable to add item to scheduler: unknown overlap policy '%s'", item.overlap)
+	}
+
+	switch item.catchUp {
+	case "":
+		item.catchUp = CatchUpOnce
+	case CatchUpOnce, CatchUpSkip, CatchUpAll:
+	default:
+		return fmt.Errorf("unable to add item to scheduler: unknown catch-up policy '%s'", item.catchUp)
+	}
+
+	if scheduler.find(item.status.Name) != nil {
+		return fmt.Errorf("unable to add item to scheduler: job %s already exists", item.status.Name)
+	}
+
+	scheduler.items = append(scheduler.items, item)
+
+	if scheduler.IsRunning() {
+		item.start(scheduler.ctx, scheduler.workers, &scheduler.inflight)
+	}
+
+	return nil
+}
+
+// Remove removes job from scheduler. Run of job in progress is not interrupted
+func (scheduler *Scheduler) Remove(name string) error {
+	scheduler.mtx.Lock()
+	defer scheduler.mtx.Unlock()
+
+	for i, item := range scheduler.items {
+		if item.status.Name != name {
+			continue
+		}
+
+		if scheduler.IsRunning() {
+			item.done <- struct{}{}
+		}
+		scheduler.items = append(scheduler.items[:i], scheduler.items[i+1:]...)
+		return nil
+	}
+
+	return fmt.Errorf("unable to remove job: job %s not found", name)
+}
+
+// SetInterval changes job to run repeatedly with given duration between runs
+func (scheduler *Scheduler) SetInterval(name string, duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("unable to change schedule of job %s: duration must be positive", name)
+	}
+
+	return scheduler.SetSchedule(name, Every(duration))
+}
+
+// SetSchedule changes schedule of job. Next run of running job is planned according to new schedule
+func (scheduler *Scheduler) SetSchedule(name string, schedule Schedule) error {
+	return scheduler.update(name, func(item *item) {
+		item.schedule = schedule
+	})
+}
+
+// Pause pauses regular runs of job. Paused job can still be run by RunNow
+func (scheduler *Scheduler) Pause(name string) error {
+	return scheduler.update(name, func(item *item) {
+		item.status.Paused = true
+		item.status.NextRun = time.Time{}
+	})
+}
+
+// Resume resumes regular runs of paused job
+func (scheduler *Scheduler) Resume(name string) error {
+	return scheduler.update(name, func(item *item) {
+		item.status.Paused = false
 	})
+}
 
+// RunNow runs job immediately, regardless of its schedule and paused state. Error is returned if job is in blackout
+// or outside of its windows (see ForceRunNow).
+// If job is being run, new run is handled according to overlap policy of job, error is returned if it is skipped
+func (scheduler *Scheduler) RunNow(name string) error {
+	return scheduler.runNow(name, false, nil)
+}
+
+// ForceRunNow runs job immediately like RunNow, also if job is in blackout or outside of its windows
+func (scheduler *Scheduler) ForceRunNow(name string) error {
+	return scheduler.runNow(name, true, nil)
+}
+
+// RunNowWait runs job immediately like RunNow and waits until the run finishes or ctx is done.
+// If job is being run and its overlap policy is OverlapQueue, it waits for the queued run.
+// RunError is returned if the run fails
+func (scheduler *Scheduler) RunNowWait(ctx context.Context, name string) error {
+	return scheduler.runNowWait(ctx, name, false)
+}
+
+// ForceRunNowWait runs job immediately like ForceRunNow and waits until the run finishes like RunNowWait
+func (scheduler *Scheduler) ForceRunNowWait(ctx context.Context, name string) error {
+	return scheduler.runNowWait(ctx, name, true)
+}
+
+func (scheduler *Scheduler) runNowWait(ctx context.Context, name string, force bool) error {
+	result := make(chan error, 1)
+	err := scheduler.runNow(name, force, result)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("unable to wait for run of job %s: %s", name, ctx.Err())
+	}
+}
+
+// runNow dispatches manual run of job, result of the run is sent to result channel if it is not nil
+func (scheduler *Scheduler) runNow(name string, force bool, result chan error) error {
+	scheduler.mtx.Lock()
+	defer scheduler.mtx.Unlock()
+
+	if !scheduler.IsRunning() {
+		return fmt.Errorf("unable to run job %s: scheduler is not running", name)
+	}
+
+	item := scheduler.find(name)
+	if item == nil {
+		return fmt.Errorf("unable to run job: job %s not found", name)
+	}
+
+	if !force && !item.isAllowed(item.clock.Now()) {
+		return fmt.Errorf("unable to run job %s: job is in blackout or outside of its windows", name)
+	}
+
+	if !item.dispatch(true, result) {
+		return fmt.Errorf("unable to run job %s: job is already running", name)
+	}
 	return nil
 }
 
-// AddFunc adds function to scheduler
-func (scheduler *Scheduler) AddFunc(fn func(), duration time.Duration) error {
-	return scheduler.Add(JobFunc(fn), duration)
+// update changes job with given name and notifies it about the change
+func (scheduler *Scheduler) update(name string, change func(item *item)) error {
+	scheduler.mtx.Lock()
+	defer scheduler.mtx.Unlock()
+
+	item := scheduler.find(name)
+	if item == nil {
+		return fmt.Errorf("unable to update job: job %s not found", name)
+	}
+
+	item.mtx.Lock()
+	change(item)
+	item.mtx.Unlock()
+
+	notify(item.wake)
+	return nil
+}
+
+// notify sends notification to buffered channel without blocking, pending notification is not duplicated
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// Jobs returns status of all jobs in order they were added
+func (scheduler *Scheduler) Jobs() []JobStatus {
+	scheduler.mtx.Lock()
+	defer scheduler.mtx.Unlock()
+
+	result := make([]JobStatus, 0, len(scheduler.items))
+	for _, item := range scheduler.items {
+		result = append(result, item.getStatus())
+	}
+
+	return result
+}
+
+// find returns item with given name or nil. Scheduler must be locked
+func (scheduler *Scheduler) find(name string) *item {
+	for _, item := range scheduler.items {
+		if item.status.Name == name {
+			return item
+		}
+	}
+	return nil
 }
 
 // Start starts scheduler by running all its jobs repeatedly
 func (scheduler *Scheduler) Start() {
+	scheduler.mtx.Lock()
+	defer scheduler.mtx.Unlock()
+
 	if scheduler.IsRunning() {
 		return
 	}
 
 	scheduler.setIsRunning(true)
 
-	for i := range scheduler.items {
-		scheduler.runItem(i)
+	if scheduler.workers == nil && scheduler.Workers > 0 {
+		scheduler.workers = make(chan struct{}, scheduler.Workers)
+	}
+
+	scheduler.ctx, scheduler.cancel = context.WithCancel(context.Background())
+
+	for _, item := range scheduler.items {
+		item.start(scheduler.ctx, scheduler.workers, &scheduler.inflight)
 	}
 }
 
-// Stop stops scheduler
+// Stop stops scheduler and cancels contexts of runs of jobs in progress. It does not wait for the runs to finish
 func (scheduler *Scheduler) Stop() {
+	scheduler.mtx.Lock()
+	defer scheduler.mtx.Unlock()
+
 	if !scheduler.IsRunning() {
 		return
 	}
 
+	scheduler.cancel()
 	for _, item := range scheduler.items {
 		item.done <- struct{}{}
+		item.setNextRun(time.Time{})
 	}
 
 	defer scheduler.setIsRunning(false)
 }
 
-func (scheduler *Scheduler) runItem(index int) {
-	ticker := time.NewTicker(scheduler.items[index].duration)
+// Shutdown stops scheduler and waits until runs of jobs in progress finish or ctx is done.
+// If ctx is done first, names of jobs that are still running are returned together with error of ctx
+func (scheduler *Scheduler) Shutdown(ctx context.Context) ([]string, error) {
+	scheduler.Stop()
 
+	finished := make(chan struct{})
 	go func() {
+		scheduler.inflight.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil, nil
+	case <-ctx.Done():
+	}
+
+	var running []string
+	for _, job := range scheduler.Jobs() {
+		if job.Running {
+			running = append(running, job.Name)
+		}
+	}
+
+	return running, ctx.Err()
+}
+
+func (item *item) start(ctx context.Context, workers chan struct{}, inflight *sync.WaitGroup) {
+	item.mtx.Lock()
+	item.active = true
+	item.ctx = ctx
+	item.workers = workers
+	item.inflight = inflight
+	item.mtx.Unlock()
+
+	// planned is next regular run according to schedule, regular is planned run delayed by jitter,
+	// next may be earlier than regular if failed run is retried or job is run on start.
+	// pending is number of missed runs to be run immediately one after another
+	now := item.clock.Now()
+	planned := item.nextPlanned(now.Add(item.initialDelay))
+	pending := 0
+	if last := item.lastSuccess(); !last.IsZero() {
+		planned, pending = item.restore(last, now)
+	} else if item.runOnStart {
+		pending = 1
+	}
+	regular := item.allowedAt(item.withJitter(planned))
+	next := regular
+	if pending > 0 {
+		next = item.allowedAt(now.Add(item.initialDelay))
+	}
+	item.setNextRun(next)
+
+	go func() {
+		var backoff time.Duration
+		var timer clock.Timer
+		var timerC <-chan time.Time
+
+		// timer is reset only if time of next run changes
+		reset := func() {
+			if timer != nil {
+				timer.Stop()
+			}
+			timer, timerC = nil, nil
+			if !next.IsZero() {
+				timer = item.clock.NewTimer(next.Sub(item.clock.Now()))
+				timerC = timer.C()
+			}
+			item.setNextRun(next)
+		}
+		reset()
+
 		for {
+			previous := next
+
 			select {
-			case <-ticker.C:
-				scheduler.items[index].job.Run()
-				continue
-			case <-scheduler.items[index].done:
-				ticker.Stop()
+			case <-timerC:
+				timer, timerC = nil, nil
+				now := item.clock.Now()
+				if !item.isAllowed(now) {
+					// run is postponed until job is allowed to run
+					next = item.allowedAt(now)
+					break
+				}
+				if item.dispatch(false, nil) && pending > 0 {
+					pending--
+				}
+
+				// runs planned while job was being dispatched or postponed are skipped
+				now = item.clock.Now()
+				if !planned.IsZero() && !planned.After(now) {
+					for !planned.IsZero() && !planned.After(now) {
+						planned = item.nextPlanned(planned)
+					}
+					regular = item.allowedAt(item.withJitter(planned))
+				}
+				next = regular
+			case <-item.finished:
+				next = regular
+				if item.lastFailed() {
+					backoff = item.retry.backoff(backoff)
+					if retry := item.allowedAt(item.clock.Now().Add(backoff)); backoff > 0 && !item.isPaused() && (regular.IsZero() || retry.Before(regular)) {
+						next = retry
+					}
+				} else {
+					backoff = 0
+					if pending > 0 && !item.isPaused() {
+						next = item.allowedAt(item.clock.Now())
+					}
+				}
+			case <-item.wake:
+				// schedule or paused state changed, pending retries are dropped
+				planned = item.nextPlanned(item.clock.Now())
+				regular = item.allowedAt(item.withJitter(planned))
+				next = regular
+				backoff = 0
+				pending = 0
+			case <-item.done:
+				if timer != nil {
+					timer.Stop()
+				}
+				item.mtx.Lock()
+				item.active = false
+				item.mtx.Unlock()
 				return
 			}
+
+			if timer == nil || !next.Equal(previous) {
+				reset()
+			}
 		}
 	}()
 }
+
+// dispatch starts new run of job according to its overlap policy. Regular runs of paused job are skipped.
+// Result of the run is sent to result channel if it is not nil. Returns false if run was skipped
+func (item *item) dispatch(manual bool, result chan error) bool {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	if item.status.Paused && !manual {
+		return false
+	}
+
+	if item.runs > 0 {
+		switch item.overlap {
+		case OverlapQueue:
+			item.queued = true
+			if result != nil {
+				item.waiters = append(item.waiters, result)
+			}
+			return true
+		case OverlapAllow:
+		default:
+			return false
+		}
+	}
+
+	var waiters []chan error
+	if result != nil {
+		waiters = append(waiters, result)
+	}
+
+	item.runs++
+	item.status.Running = true
+	item.inflight.Add(1)
+	go item.execute(item.ctx, item.workers, item.inflight, waiters)
+	return true
+}
+
+// execute runs job when worker is available, followed by queued run if any. Result of run is sent to waiters
+func (item *item) execute(ctx context.Context, workers chan struct{}, inflight *sync.WaitGroup, waiters []chan error) {
+	defer inflight.Done()
+	name := item.getStatus().Name
+	stopped := fmt.Errorf("unable to run job %s: scheduler is stopped", name)
+
+	for {
+		acquired := workers == nil
+		if !acquired {
+			select {
+			case workers <- struct{}{}:
+				acquired = true
+			case <-ctx.Done():
+			}
+		}
+
+		// job may be stopped while waiting for worker
+		result := stopped
+		if acquired && ctx.Err() == nil {
+			result = nil
+			if err := item.run(ctx); err != nil {
+				result = &RunError{Name: name, Err: err}
+			}
+		}
+		if acquired && workers != nil {
+			<-workers
+		}
+		for _, waiter := range waiters {
+			waiter <- result
+		}
+
+		item.mtx.Lock()
+		queued := item.queued && item.active
+		item.queued = false
+		waiters = item.waiters
+		item.waiters = nil
+		if !queued {
+			item.runs--
+			item.status.Running = item.runs > 0
+		}
+		item.mtx.Unlock()
+
+		notify(item.finished)
+		if !queued {
+			// queued run is dropped because job was stopped
+			for _, waiter := range waiters {
+				waiter <- stopped
+			}
+			return
+		}
+	}
+}
+
+// nextPlanned returns next regular run after given time, zero if job is paused or schedule has no more runs
+func (item *item) nextPlanned(t time.Time) time.Time {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	if item.status.Paused {
+		return time.Time{}
+	}
+	return item.schedule.Next(t)
+}
+
+// withJitter returns time delayed by random jitter of job
+func (item *item) withJitter(t time.Time) time.Time {
+	if t.IsZero() || item.jitter <= 0 {
+		return t
+	}
+	return t.Add(time.Duration(rand.Int63n(int64(item.jitter))))
+}
+
+func (item *item) lastFailed() bool {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+	return item.failed
+}
+
+func (item *item) isPaused() bool {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+	return item.status.Paused
+}
+
+// run runs job and records its result, error of the run is returned
+func (item *item) run(ctx context.Context) error {
+	if item.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, item.timeout)
+		defer cancel()
+	}
+
+	start := item.clock.Now()
+	item.mtx.Lock()
+	item.status.LastStart = start
+	item.mtx.Unlock()
+
+	err := item.job.Run(ctx)
+
+	if err == nil && item.store != nil {
+		// failure to persist state only affects planning of runs after restart
+		_ = item.store.Save(item.getStatus().Name, start)
+	}
+
+	finish := item.clock.Now()
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+	item.status.LastFinish = finish
+	item.status.LastDuration = finish.Sub(start)
+	item.failed = err != nil
+	if err != nil {
+		item.status.LastError = err.Error()
+		item.status.Failures++
+	} else {
+		item.status.LastError = ""
+		item.status.Successes++
+	}
+	return err
+}
+
+func (item *item) getStatus() JobStatus {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+	return item.status
+}
+
+func (item *item) setNextRun(next time.Time) {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+	item.status.NextRun = next
+}
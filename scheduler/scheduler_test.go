@@ -1,10 +1,13 @@
 package scheduler_test
 
 import (
+	"context"
+	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/danielkraic/idmapper/clock"
 	"github.com/danielkraic/idmapper/scheduler"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,17 +24,62 @@ func (job *Job) Calls() int {
 	return int(atomic.LoadInt32(&job.calls))
 }
 
+var testStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newTestScheduler creates scheduler using fake clock set to testStart
+func newTestScheduler() (*scheduler.Scheduler, *clock.Fake) {
+	fakeClock := clock.NewFake(testStart)
+	return &scheduler.Scheduler{Clock: fakeClock}, fakeClock
+}
+
+// waitFor waits until condition is satisfied, test fails if it is not satisfied within a second
+func waitFor(t *testing.T, condition func() bool, msgAndArgs ...interface{}) {
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			assert.Fail(t, "condition not satisfied", msgAndArgs...)
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// settle waits until all runs of jobs finished and jobs planned their next runs
+func settle(t *testing.T, jobScheduler *scheduler.Scheduler, fakeClock *clock.Fake) {
+	waitFor(t, func() bool {
+		for _, job := range jobScheduler.Jobs() {
+			if job.Running || (!job.NextRun.IsZero() && !job.NextRun.After(fakeClock.Now())) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// advance moves fake clock forward by d in steps, jobs are settled after each step
+func advance(t *testing.T, jobScheduler *scheduler.Scheduler, fakeClock *clock.Fake, d time.Duration, step time.Duration) {
+	for d > 0 {
+		if step > d {
+			step = d
+		}
+		settle(t, jobScheduler, fakeClock)
+		fakeClock.Advance(step)
+		d -= step
+	}
+	settle(t, jobScheduler, fakeClock)
+}
+
 func TestJob(t *testing.T) {
 	job := &Job{}
 
-	scheduler := scheduler.Scheduler{}
-	err := scheduler.Add(job, time.Second)
+	jobScheduler, fakeClock := newTestScheduler()
+	err := jobScheduler.Add(job, time.Second)
 	assert.Nil(t, err)
 
-	go scheduler.Start()
+	jobScheduler.Start()
 
-	time.Sleep(2500 * time.Millisecond)
-	scheduler.Stop()
+	advance(t, jobScheduler, fakeClock, 2500*time.Millisecond, 100*time.Millisecond)
+	jobScheduler.Stop()
 
 	assert.Equal(t, 2, job.Calls())
 }
@@ -42,14 +90,14 @@ func TestJobFunc(t *testing.T) {
 		atomic.AddInt32(&counter, 1)
 	}
 
-	scheduler := scheduler.Scheduler{}
-	err := scheduler.AddFunc(fn, 100*time.Millisecond)
+	jobScheduler, fakeClock := newTestScheduler()
+	err := jobScheduler.AddFunc(fn, 100*time.Millisecond)
 	assert.Nil(t, err)
 
-	go scheduler.Start()
+	jobScheduler.Start()
 
-	time.Sleep(350 * time.Millisecond)
-	scheduler.Stop()
+	advance(t, jobScheduler, fakeClock, 350*time.Millisecond, 50*time.Millisecond)
+	jobScheduler.Stop()
 
 	assert.Equal(t, int32(3), atomic.LoadInt32(&counter))
 }
@@ -64,29 +112,34 @@ func TestAlreadyRunning(t *testing.T) {
 		atomic.AddInt32(&counter2, 1)
 	}
 
-	scheduler := scheduler.Scheduler{}
-	err := scheduler.AddFunc(fn1, 100*time.Millisecond)
+	jobScheduler, fakeClock := newTestScheduler()
+	err := jobScheduler.AddFunc(fn1, 100*time.Millisecond)
 	assert.Nil(t, err)
 
 	// start scheduler multiple times, only one instance of each job should be launched
-	go scheduler.Start()
-	go scheduler.Start()
-	go scheduler.Start()
+	go jobScheduler.Start()
+	go jobScheduler.Start()
+	go jobScheduler.Start()
+
+	waitFor(t, func() bool {
+		return !jobScheduler.Jobs()[0].NextRun.IsZero()
+	})
 
-	time.Sleep(100 * time.Millisecond)
+	advance(t, jobScheduler, fakeClock, 150*time.Millisecond, 50*time.Millisecond)
 
-	err = scheduler.AddFunc(fn2, 100*time.Millisecond)
-	assert.EqualError(t, err, "unable to add item to scheduler: scheduler is already running")
+	// job added to running scheduler is started immediately
+	err = jobScheduler.AddFunc(fn2, 100*time.Millisecond)
+	assert.Nil(t, err)
 
-	time.Sleep(250 * time.Millisecond)
+	advance(t, jobScheduler, fakeClock, 200*time.Millisecond, 50*time.Millisecond)
 
 	// stop scheduler multiple times, only first Stop() will stop jobs
-	scheduler.Stop()
-	scheduler.Stop()
-	scheduler.Stop()
+	jobScheduler.Stop()
+	jobScheduler.Stop()
+	jobScheduler.Stop()
 
 	assert.Equal(t, int32(3), atomic.LoadInt32(&counter1))
-	assert.Equal(t, int32(0), atomic.LoadInt32(&counter2))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&counter2))
 }
 
 func TestMultipleJobs(t *testing.T) {
@@ -105,18 +158,491 @@ func TestMultipleJobs(t *testing.T) {
 
 	totalDuration := 1950 * time.Millisecond
 
-	scheduler := scheduler.Scheduler{}
+	jobScheduler, fakeClock := newTestScheduler()
 	for _, job := range jobs {
-		err := scheduler.Add(job.job, job.duration)
+		err := jobScheduler.Add(job.job, job.duration)
 		assert.Nil(t, err)
 	}
 
-	go scheduler.Start()
+	jobScheduler.Start()
 
-	time.Sleep(totalDuration)
-	scheduler.Stop()
+	advance(t, jobScheduler, fakeClock, totalDuration, 50*time.Millisecond)
+	jobScheduler.Stop()
 
 	for _, job := range jobs {
 		assert.Equal(t, job.expectedCalls, job.job.Calls())
 	}
 }
+
+func TestJobWithContextRetry(t *testing.T) {
+	var calls int32 = 0
+	job := scheduler.JobWithContextFunc(func(ctx context.Context) error {
+		// first two runs fail
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+
+	jobScheduler, fakeClock := newTestScheduler()
+	err := jobScheduler.AddWithContext(job, scheduler.Every(time.Second), scheduler.WithRetry(scheduler.RetryPolicy{
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     100 * time.Millisecond,
+	}))
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+
+	// runs at 1s (failed), 1.05s (failed, retry), 1.15s (retry)
+	for _, expected := range []struct {
+		calls   int32
+		nextRun time.Duration
+	}{
+		{1, 1050 * time.Millisecond},
+		{2, 1150 * time.Millisecond},
+		{3, 2000 * time.Millisecond},
+	} {
+		fakeClock.Set(jobScheduler.Jobs()[0].NextRun)
+
+		waitFor(t, func() bool {
+			return jobScheduler.Jobs()[0].NextRun.Equal(testStart.Add(expected.nextRun))
+		})
+		assert.Equal(t, expected.calls, atomic.LoadInt32(&calls))
+	}
+
+	jobScheduler.Stop()
+}
+
+func TestJobWithContextTimeout(t *testing.T) {
+	errs := make(chan error, 10)
+	job := scheduler.JobWithContextFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		errs <- ctx.Err()
+		return ctx.Err()
+	})
+
+	// timeout is measured by real time
+	jobScheduler, fakeClock := newTestScheduler()
+	err := jobScheduler.AddWithContext(job, scheduler.Every(100*time.Millisecond), scheduler.WithTimeout(50*time.Millisecond))
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+
+	advance(t, jobScheduler, fakeClock, 180*time.Millisecond, 10*time.Millisecond)
+	jobScheduler.Stop()
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, context.DeadlineExceeded, <-errs)
+}
+
+func TestJobs(t *testing.T) {
+	var calls int32 = 0
+	job := scheduler.JobWithContextFunc(func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+
+	jobScheduler, fakeClock := newTestScheduler()
+	err := jobScheduler.AddWithContext(job, scheduler.Every(100*time.Millisecond), scheduler.WithName("reload"))
+	assert.Nil(t, err)
+	err = jobScheduler.AddFunc(func() {}, time.Hour)
+	assert.Nil(t, err)
+
+	err = jobScheduler.AddFunc(func() {}, time.Hour, scheduler.WithName("reload"))
+	assert.EqualError(t, err, "unable to add item to scheduler: job reload already exists")
+
+	jobs := jobScheduler.Jobs()
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, "reload", jobs[0].Name)
+	assert.Equal(t, "job-2", jobs[1].Name)
+	assert.True(t, jobs[0].NextRun.IsZero())
+
+	jobScheduler.Start()
+
+	advance(t, jobScheduler, fakeClock, 150*time.Millisecond, 50*time.Millisecond)
+	jobs = jobScheduler.Jobs()
+	assert.Equal(t, uint64(0), jobs[0].Successes)
+	assert.Equal(t, uint64(1), jobs[0].Failures)
+	assert.Equal(t, "failed", jobs[0].LastError)
+	assert.False(t, jobs[0].Running)
+	assert.Equal(t, testStart.Add(100*time.Millisecond), jobs[0].LastStart)
+	assert.Equal(t, testStart.Add(200*time.Millisecond), jobs[0].NextRun)
+	assert.Equal(t, testStart.Add(time.Hour), jobs[1].NextRun)
+
+	advance(t, jobScheduler, fakeClock, 100*time.Millisecond, 50*time.Millisecond)
+	jobScheduler.Stop()
+
+	jobs = jobScheduler.Jobs()
+	assert.Equal(t, uint64(1), jobs[0].Successes)
+	assert.Equal(t, uint64(1), jobs[0].Failures)
+	assert.Equal(t, "", jobs[0].LastError)
+	assert.Equal(t, testStart.Add(200*time.Millisecond), jobs[0].LastStart)
+	assert.Equal(t, testStart.Add(200*time.Millisecond), jobs[0].LastFinish)
+	assert.True(t, jobs[0].NextRun.IsZero())
+	assert.Equal(t, uint64(0), jobs[1].Successes+jobs[1].Failures)
+}
+
+func TestRemove(t *testing.T) {
+	job1, job2 := &Job{}, &Job{}
+
+	jobScheduler, fakeClock := newTestScheduler()
+	assert.Nil(t, jobScheduler.Add(job1, 100*time.Millisecond, scheduler.WithName("first")))
+	assert.Nil(t, jobScheduler.Add(job2, 100*time.Millisecond, scheduler.WithName("second")))
+
+	jobScheduler.Start()
+
+	advance(t, jobScheduler, fakeClock, 250*time.Millisecond, 50*time.Millisecond)
+	assert.Nil(t, jobScheduler.Remove("first"))
+	assert.EqualError(t, jobScheduler.Remove("first"), "unable to remove job: job first not found")
+
+	advance(t, jobScheduler, fakeClock, 200*time.Millisecond, 50*time.Millisecond)
+	jobScheduler.Stop()
+
+	assert.Equal(t, 2, job1.Calls())
+	assert.Equal(t, 4, job2.Calls())
+
+	jobs := jobScheduler.Jobs()
+	assert.Equal(t, 1, len(jobs))
+	assert.Equal(t, "second", jobs[0].Name)
+}
+
+func TestSetInterval(t *testing.T) {
+	job := &Job{}
+
+	jobScheduler, fakeClock := newTestScheduler()
+	assert.Nil(t, jobScheduler.Add(job, time.Hour, scheduler.WithName("job")))
+
+	jobScheduler.Start()
+
+	advance(t, jobScheduler, fakeClock, 50*time.Millisecond, 50*time.Millisecond)
+	assert.Nil(t, jobScheduler.SetInterval("job", 100*time.Millisecond))
+	assert.EqualError(t, jobScheduler.SetInterval("job", 0), "unable to change schedule of job job: duration must be positive")
+	assert.EqualError(t, jobScheduler.SetInterval("unknown", time.Second), "unable to update job: job unknown not found")
+
+	// next run is planned according to new interval
+	waitFor(t, func() bool {
+		return jobScheduler.Jobs()[0].NextRun.Equal(testStart.Add(150 * time.Millisecond))
+	})
+
+	advance(t, jobScheduler, fakeClock, 250*time.Millisecond, 50*time.Millisecond)
+	jobScheduler.Stop()
+
+	assert.Equal(t, 2, job.Calls())
+}
+
+func TestPauseResume(t *testing.T) {
+	job := &Job{}
+
+	jobScheduler, fakeClock := newTestScheduler()
+	assert.Nil(t, jobScheduler.Add(job, 100*time.Millisecond, scheduler.WithName("job")))
+
+	jobScheduler.Start()
+
+	advance(t, jobScheduler, fakeClock, 150*time.Millisecond, 50*time.Millisecond)
+	assert.Nil(t, jobScheduler.Pause("job"))
+
+	jobs := jobScheduler.Jobs()
+	assert.True(t, jobs[0].Paused)
+	assert.True(t, jobs[0].NextRun.IsZero())
+
+	advance(t, jobScheduler, fakeClock, 300*time.Millisecond, 50*time.Millisecond)
+	assert.Equal(t, 1, job.Calls())
+
+	assert.Nil(t, jobScheduler.Resume("job"))
+	waitFor(t, func() bool {
+		return jobScheduler.Jobs()[0].NextRun.Equal(testStart.Add(550 * time.Millisecond))
+	})
+
+	advance(t, jobScheduler, fakeClock, 250*time.Millisecond, 50*time.Millisecond)
+	jobScheduler.Stop()
+
+	assert.Equal(t, 3, job.Calls())
+	assert.False(t, jobScheduler.Jobs()[0].Paused)
+}
+
+func TestRunNow(t *testing.T) {
+	job := &Job{}
+
+	jobScheduler, fakeClock := newTestScheduler()
+	assert.Nil(t, jobScheduler.Add(job, time.Hour, scheduler.WithName("job")))
+	assert.EqualError(t, jobScheduler.RunNow("job"), "unable to run job job: scheduler is not running")
+
+	jobScheduler.Start()
+
+	assert.Nil(t, jobScheduler.RunNow("job"))
+	assert.EqualError(t, jobScheduler.RunNow("unknown"), "unable to run job: job unknown not found")
+	settle(t, jobScheduler, fakeClock)
+	assert.Equal(t, 1, job.Calls())
+
+	// paused job can be run manually
+	assert.Nil(t, jobScheduler.Pause("job"))
+	assert.Nil(t, jobScheduler.RunNow("job"))
+	settle(t, jobScheduler, fakeClock)
+	jobScheduler.Stop()
+
+	assert.Equal(t, 2, job.Calls())
+}
+
+func TestRunNowWait(t *testing.T) {
+	var calls int32
+	fail := int32(0)
+	release := make(chan struct{})
+	job := scheduler.JobWithContextFunc(func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			<-release
+		}
+		if atomic.LoadInt32(&fail) == 1 {
+			return fmt.Errorf("failure")
+		}
+		return nil
+	})
+
+	jobScheduler, _ := newTestScheduler()
+	assert.Nil(t, jobScheduler.AddWithContext(job, scheduler.Every(time.Hour), scheduler.WithName("job"), scheduler.WithOverlap(scheduler.OverlapQueue)))
+	assert.EqualError(t, jobScheduler.RunNowWait(context.Background(), "job"), "unable to run job job: scheduler is not running")
+
+	jobScheduler.Start()
+
+	assert.Nil(t, jobScheduler.RunNowWait(context.Background(), "job"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// waiting is interrupted by ctx, run is not
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.EqualError(t, jobScheduler.RunNowWait(ctx, "job"), "unable to wait for run of job job: context deadline exceeded")
+
+	// run requested while job is being run waits for queued run
+	atomic.StoreInt32(&fail, 1)
+	result := make(chan error, 1)
+	go func() {
+		result <- jobScheduler.ForceRunNowWait(context.Background(), "job")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case <-result:
+		assert.Fail(t, "queued run finished before running one")
+	default:
+	}
+	close(release)
+
+	err := <-result
+	assert.EqualError(t, err, "run of job job failed: failure")
+	_, failed := err.(*scheduler.RunError)
+	assert.True(t, failed)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	jobScheduler.Stop()
+}
+
+func TestOverlap(t *testing.T) {
+	tests := []struct {
+		policy        scheduler.OverlapPolicy
+		expectedCalls int32
+	}{
+		// runs requested at 200ms and 300ms are skipped
+		{scheduler.OverlapSkip, 1},
+		// runs requested at 200ms and 300ms are merged into one run after first run finishes
+		{scheduler.OverlapQueue, 2},
+		// runs requested at 200ms and 300ms run concurrently with first run
+		{scheduler.OverlapAllow, 3},
+	}
+
+	for _, test := range tests {
+		var calls int32
+		release := make(chan struct{})
+		job := scheduler.JobWithContextFunc(func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return nil
+		})
+
+		jobScheduler, fakeClock := newTestScheduler()
+		err := jobScheduler.AddWithContext(job, scheduler.Every(100*time.Millisecond), scheduler.WithOverlap(test.policy))
+		assert.Nil(t, err)
+
+		jobScheduler.Start()
+
+		// runs block until released
+		for i := 0; i < 3; i++ {
+			fakeClock.Set(jobScheduler.Jobs()[0].NextRun)
+			waitFor(t, func() bool {
+				return jobScheduler.Jobs()[0].NextRun.After(fakeClock.Now())
+			})
+		}
+
+		close(release)
+		settle(t, jobScheduler, fakeClock)
+		jobScheduler.Stop()
+
+		assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls), string(test.policy))
+	}
+}
+
+func TestOverlapInvalid(t *testing.T) {
+	jobScheduler := scheduler.Scheduler{}
+	err := jobScheduler.AddFunc(func() {}, time.Second, scheduler.WithOverlap("unknown"))
+	assert.EqualError(t, err, "unable to add item to scheduler: unknown overlap policy 'unknown'")
+}
+
+func TestRunNowSkipped(t *testing.T) {
+	release := make(chan struct{})
+	job := scheduler.JobWithContextFunc(func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	jobScheduler, _ := newTestScheduler()
+	assert.Nil(t, jobScheduler.AddWithContext(job, scheduler.Every(time.Hour), scheduler.WithName("job")))
+
+	jobScheduler.Start()
+
+	assert.Nil(t, jobScheduler.RunNow("job"))
+	assert.EqualError(t, jobScheduler.RunNow("job"), "unable to run job job: job is already running")
+
+	close(release)
+	jobScheduler.Stop()
+}
+
+func TestWorkers(t *testing.T) {
+	var started, running, maxRunning int32
+	release := make(chan struct{})
+	job := scheduler.JobWithContextFunc(func(ctx context.Context) error {
+		atomic.AddInt32(&started, 1)
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+
+	jobScheduler, fakeClock := newTestScheduler()
+	jobScheduler.Workers = 2
+	for i := 0; i < 4; i++ {
+		err := jobScheduler.AddWithContext(job, scheduler.Every(50*time.Millisecond))
+		assert.Nil(t, err)
+	}
+
+	jobScheduler.Start()
+	fakeClock.BlockUntil(4)
+	fakeClock.Advance(50 * time.Millisecond)
+
+	// only two runs are executed, others wait for free worker
+	waitFor(t, func() bool {
+		return atomic.LoadInt32(&started) == 2
+	})
+	for _, job := range jobScheduler.Jobs() {
+		assert.True(t, job.Running)
+	}
+
+	close(release)
+	settle(t, jobScheduler, fakeClock)
+	jobScheduler.Stop()
+
+	assert.Equal(t, int32(4), atomic.LoadInt32(&started))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+}
+
+func TestJitter(t *testing.T) {
+	jobScheduler, _ := newTestScheduler()
+	err := jobScheduler.AddFunc(func() {}, time.Hour, scheduler.WithJitter(time.Minute))
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+	next := jobScheduler.Jobs()[0].NextRun
+	jobScheduler.Stop()
+
+	assert.False(t, next.Before(testStart.Add(time.Hour)))
+	assert.True(t, next.Before(testStart.Add(time.Hour+time.Minute)))
+}
+
+func TestInitialDelay(t *testing.T) {
+	jobScheduler, _ := newTestScheduler()
+	err := jobScheduler.AddFunc(func() {}, time.Hour, scheduler.WithInitialDelay(time.Minute))
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+	next := jobScheduler.Jobs()[0].NextRun
+	jobScheduler.Stop()
+
+	assert.Equal(t, testStart.Add(time.Hour+time.Minute), next)
+}
+
+func TestRunOnStart(t *testing.T) {
+	job1, job2 := &Job{}, &Job{}
+
+	jobScheduler, fakeClock := newTestScheduler()
+	assert.Nil(t, jobScheduler.Add(job1, time.Hour, scheduler.WithRunOnStart()))
+	assert.Nil(t, jobScheduler.Add(job2, time.Hour, scheduler.WithRunOnStart(), scheduler.WithInitialDelay(200*time.Millisecond)))
+
+	jobScheduler.Start()
+
+	settle(t, jobScheduler, fakeClock)
+	assert.Equal(t, 1, job1.Calls())
+	assert.Equal(t, 0, job2.Calls())
+
+	advance(t, jobScheduler, fakeClock, 300*time.Millisecond, 100*time.Millisecond)
+	jobScheduler.Stop()
+
+	assert.Equal(t, 1, job1.Calls())
+	assert.Equal(t, 1, job2.Calls())
+}
+
+func TestShutdown(t *testing.T) {
+	started := make(chan struct{})
+	errs := make(chan error, 1)
+	job := scheduler.JobWithContextFunc(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		errs <- ctx.Err()
+		return ctx.Err()
+	})
+
+	jobScheduler, _ := newTestScheduler()
+	assert.Nil(t, jobScheduler.AddWithContext(job, scheduler.Every(time.Hour), scheduler.WithName("job")))
+
+	jobScheduler.Start()
+	assert.Nil(t, jobScheduler.RunNow("job"))
+	<-started
+
+	// context of running job is cancelled, shutdown waits until job returns
+	running, err := jobScheduler.Shutdown(context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, running)
+	assert.Equal(t, context.Canceled, <-errs)
+	assert.False(t, jobScheduler.IsRunning())
+	assert.False(t, jobScheduler.Jobs()[0].Running)
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	// job ignores cancelled context
+	stuck := func() {
+		close(started)
+		<-release
+	}
+
+	jobScheduler, _ := newTestScheduler()
+	assert.Nil(t, jobScheduler.AddFunc(stuck, time.Hour, scheduler.WithName("stuck")))
+	assert.Nil(t, jobScheduler.AddFunc(func() {}, time.Hour, scheduler.WithName("idle")))
+
+	jobScheduler.Start()
+	assert.Nil(t, jobScheduler.RunNow("stuck"))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	running, err := jobScheduler.Shutdown(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, []string{"stuck"}, running)
+}
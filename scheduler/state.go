@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// maxCatchUp limits number of missed runs of job run after start of scheduler
+const maxCatchUp = 100
+
+// StateStore persists state of jobs between restarts of scheduler. May be shared between goroutines
+type StateStore interface {
+	// Load returns start time of last successful run of job, zero time if it is not known
+	Load(name string) (time.Time, error)
+	// Save stores start time of last successful run of job
+	Save(name string, lastSuccess time.Time) error
+}
+
+// CatchUpPolicy defines handling of runs of job missed while scheduler was not running (see Scheduler.Store)
+type CatchUpPolicy string
+
+const (
+	// CatchUpOnce runs job once immediately if any run was missed
+	CatchUpOnce CatchUpPolicy = "once"
+	// CatchUpSkip skips missed runs, job is run at its next regular run
+	CatchUpSkip CatchUpPolicy = "skip"
+	// CatchUpAll runs job immediately once for every missed run, up to 100 runs
+	CatchUpAll CatchUpPolicy = "all"
+)
+
+// restore returns next regular run of job planned according to start time of its last successful run
+// and number of missed runs to be run immediately according to catch-up policy of job
+func (item *item) restore(last time.Time, now time.Time) (time.Time, int) {
+	missed := 0
+	planned := item.nextPlanned(last)
+	for !planned.IsZero() && !planned.After(now) {
+		missed++
+		if missed >= maxCatchUp {
+			planned = item.nextPlanned(now)
+			break
+		}
+		planned = item.nextPlanned(planned)
+	}
+
+	switch item.catchUp {
+	case CatchUpSkip:
+		missed = 0
+	case CatchUpAll:
+	default:
+		if missed > 1 {
+			missed = 1
+		}
+	}
+
+	return planned, missed
+}
+
+// lastSuccess returns start time of last successful run of job loaded from store, or run executed outside of scheduler
+// if it is later, zero time if it is not known
+func (item *item) lastSuccess() time.Time {
+	if item.store == nil {
+		return time.Time{}
+	}
+
+	name := item.getStatus().Name
+	last, err := item.store.Load(name)
+	if err != nil {
+		last = time.Time{}
+	}
+
+	if item.lastRun.After(last) {
+		// failure to persist state only affects planning of runs after restart
+		_ = item.store.Save(name, item.lastRun)
+		last = item.lastRun
+	}
+	return last
+}
+
+// FileStateStore is StateStore which stores state of all jobs in JSON file
+type FileStateStore struct {
+	path string
+	mtx  sync.Mutex
+}
+
+// NewFileStateStore creates FileStateStore using file at given path, file is created by first Save
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// Load returns start time of last successful run of job stored in file
+func (store *FileStateStore) Load(name string) (time.Time, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	state, err := store.read()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return state[name], nil
+}
+
+// Save stores start time of last successful run of job to file. File is replaced atomically
+func (store *FileStateStore) Save(name string, lastSuccess time.Time) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	state, err := store.read()
+	if err != nil {
+		return err
+	}
+	state[name] = lastSuccess
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduler state: %s", err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create scheduler state file: %s", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write scheduler state file %s: %s", tmp.Name(), err)
+	}
+
+	err = os.Rename(tmp.Name(), store.path)
+	if err != nil {
+		return fmt.Errorf("failed to replace scheduler state file %s: %s", store.path, err)
+	}
+
+	return nil
+}
+
+// read reads state of all jobs from file, empty state is returned if file does not exist
+func (store *FileStateStore) read() (map[string]time.Time, error) {
+	state := make(map[string]time.Time)
+
+	data, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scheduler state file %s: %s", store.path, err)
+	}
+
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode scheduler state file %s: %s", store.path, err)
+	}
+
+	return state, nil
+}
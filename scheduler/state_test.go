@@ -0,0 +1,147 @@
+package scheduler_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielkraic/idmapper/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore struct {
+	state map[string]time.Time
+	mtx   sync.Mutex
+}
+
+func (store *memoryStore) Load(name string) (time.Time, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	return store.state[name], nil
+}
+
+func (store *memoryStore) Save(name string, lastSuccess time.Time) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	store.state[name] = lastSuccess
+	return nil
+}
+
+func TestFileStateStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scheduler")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := scheduler.NewFileStateStore(filepath.Join(dir, "state.json"))
+
+	last, err := store.Load("job")
+	assert.Nil(t, err)
+	assert.True(t, last.IsZero())
+
+	assert.Nil(t, store.Save("job", testStart))
+	assert.Nil(t, store.Save("other", testStart.Add(time.Hour)))
+
+	last, err = scheduler.NewFileStateStore(filepath.Join(dir, "state.json")).Load("job")
+	assert.Nil(t, err)
+	assert.True(t, testStart.Equal(last))
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "invalid.json"), []byte("{"), 0644))
+	_, err = scheduler.NewFileStateStore(filepath.Join(dir, "invalid.json")).Load("job")
+	assert.NotNil(t, err)
+}
+
+func TestStateRestore(t *testing.T) {
+	store := &memoryStore{state: map[string]time.Time{"job": testStart.Add(-30 * time.Minute)}}
+	job := &Job{}
+
+	jobScheduler, fakeClock := newTestScheduler()
+	jobScheduler.Store = store
+	err := jobScheduler.Add(job, time.Hour, scheduler.WithName("job"), scheduler.WithRunOnStart())
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+
+	// next run is planned according to last successful run instead of running on start
+	assert.Equal(t, testStart.Add(30*time.Minute), jobScheduler.Jobs()[0].NextRun)
+
+	advance(t, jobScheduler, fakeClock, 30*time.Minute, 30*time.Minute)
+	jobScheduler.Stop()
+
+	assert.Equal(t, 1, job.Calls())
+	last, _ := store.Load("job")
+	assert.Equal(t, testStart.Add(30*time.Minute), last)
+}
+
+func TestStateLastSuccess(t *testing.T) {
+	// run at -30m was missed, but job was run outside of scheduler at -10m
+	store := &memoryStore{state: map[string]time.Time{"job": testStart.Add(-90 * time.Minute)}}
+	job := &Job{}
+
+	jobScheduler, fakeClock := newTestScheduler()
+	jobScheduler.Store = store
+	err := jobScheduler.Add(job, time.Hour, scheduler.WithName("job"), scheduler.WithLastSuccess(testStart.Add(-10*time.Minute)))
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+	settle(t, jobScheduler, fakeClock)
+	next := jobScheduler.Jobs()[0].NextRun
+	jobScheduler.Stop()
+
+	assert.Equal(t, 0, job.Calls())
+	assert.Equal(t, testStart.Add(50*time.Minute), next)
+	last, _ := store.Load("job")
+	assert.Equal(t, testStart.Add(-10*time.Minute), last)
+
+	// earlier run outside of scheduler does not override stored state
+	store = &memoryStore{state: map[string]time.Time{"job": testStart.Add(-30 * time.Minute)}}
+	jobScheduler, _ = newTestScheduler()
+	jobScheduler.Store = store
+	err = jobScheduler.Add(&Job{}, time.Hour, scheduler.WithName("job"), scheduler.WithLastSuccess(testStart.Add(-time.Hour)))
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+	next = jobScheduler.Jobs()[0].NextRun
+	jobScheduler.Stop()
+
+	assert.Equal(t, testStart.Add(30*time.Minute), next)
+	last, _ = store.Load("job")
+	assert.Equal(t, testStart.Add(-30*time.Minute), last)
+}
+
+func TestCatchUp(t *testing.T) {
+	tests := []struct {
+		policy scheduler.CatchUpPolicy
+		calls  int
+	}{
+		{"", 1},
+		{scheduler.CatchUpOnce, 1},
+		{scheduler.CatchUpSkip, 0},
+		{scheduler.CatchUpAll, 3},
+	}
+
+	for _, test := range tests {
+		// runs at -2h30m, -1h30m and -30m were missed
+		store := &memoryStore{state: map[string]time.Time{"job": testStart.Add(-3*time.Hour - 30*time.Minute)}}
+		job := &Job{}
+
+		jobScheduler, fakeClock := newTestScheduler()
+		jobScheduler.Store = store
+		err := jobScheduler.Add(job, time.Hour, scheduler.WithName("job"), scheduler.WithCatchUp(test.policy))
+		assert.Nil(t, err)
+
+		jobScheduler.Start()
+		settle(t, jobScheduler, fakeClock)
+		next := jobScheduler.Jobs()[0].NextRun
+		jobScheduler.Stop()
+
+		assert.Equal(t, test.calls, job.Calls(), test.policy)
+		assert.Equal(t, testStart.Add(30*time.Minute), next, test.policy)
+	}
+
+	jobScheduler, _ := newTestScheduler()
+	err := jobScheduler.AddFunc(func() {}, time.Hour, scheduler.WithCatchUp("unknown"))
+	assert.EqualError(t, err, "unable to add item to scheduler: unknown catch-up policy 'unknown'")
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxWindowSteps limits number of intervals evaluated when looking for time allowed by windows of job
+const maxWindowSteps = 10000
+
+// Window is set of time intervals used to restrict times when job is run (see WithBlackouts and WithWindows)
+type Window interface {
+	// Interval returns interval of window containing given time or first interval starting after it.
+	// Returns zero times if there is no such interval
+	Interval(t time.Time) (start time.Time, end time.Time)
+}
+
+// Period is one-off Window from Start (inclusive) to End (exclusive)
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Interval returns the period if it has not ended before given time
+func (period Period) Interval(t time.Time) (time.Time, time.Time) {
+	if !period.End.After(t) || !period.End.After(period.Start) {
+		return time.Time{}, time.Time{}
+	}
+	return period.Start, period.End
+}
+
+// CronWindow returns recurring Window with intervals of given duration starting at times of schedule,
+// e.g. CronWindow(ParseCron("CRON_TZ=Europe/Bratislava 0 0 28-31 * *"), 24*time.Hour) for last days of month.
+// Overlapping and adjacent intervals are merged
+func CronWindow(schedule Schedule, duration time.Duration) (Window, error) {
+	if duration <= 0 {
+		return nil, fmt.Errorf("unable to create window: duration must be positive")
+	}
+
+	return &cronWindow{schedule: schedule, duration: duration}, nil
+}
+
+type cronWindow struct {
+	schedule Schedule
+	duration time.Duration
+}
+
+func (window *cronWindow) Interval(t time.Time) (time.Time, time.Time) {
+	// first interval ending after t starts after t-duration
+	start := window.schedule.Next(t.Add(-window.duration))
+	if start.IsZero() {
+		return time.Time{}, time.Time{}
+	}
+
+	end := start.Add(window.duration)
+	next := window.schedule.Next(start)
+	for i := 0; i < maxWindowSteps && !next.IsZero() && !next.After(end); i++ {
+		end = next.Add(window.duration)
+		next = window.schedule.Next(next)
+	}
+
+	return start, end
+}
+
+// allowedAt returns first time not before given time which is outside of blackouts of job and within its windows.
+// Returns zero time if there is no such time
+func (item *item) allowedAt(t time.Time) time.Time {
+	if t.IsZero() || (len(item.blackouts) == 0 && len(item.windows) == 0) {
+		return t
+	}
+
+	for i := 0; i < maxWindowSteps; i++ {
+		moved := false
+
+		for _, blackout := range item.blackouts {
+			start, end := blackout.Interval(t)
+			if !start.IsZero() && !start.After(t) {
+				t = end
+				moved = true
+			}
+		}
+
+		if len(item.windows) > 0 {
+			var open time.Time
+			for _, window := range item.windows {
+				start, _ := window.Interval(t)
+				if start.IsZero() {
+					continue
+				}
+				if !start.After(t) {
+					open = t
+					break
+				}
+				if open.IsZero() || start.Before(open) {
+					open = start
+				}
+			}
+
+			if open.IsZero() {
+				return time.Time{}
+			}
+			if open.After(t) {
+				t = open
+				moved = true
+			}
+		}
+
+		if !moved {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// isAllowed returns true if job can be run at given time according to its blackouts and windows
+func (item *item) isAllowed(t time.Time) bool {
+	return item.allowedAt(t).Equal(t)
+}
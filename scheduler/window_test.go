@@ -0,0 +1,96 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danielkraic/idmapper/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriod(t *testing.T) {
+	period := scheduler.Period{Start: testStart.Add(time.Hour), End: testStart.Add(2 * time.Hour)}
+
+	start, end := period.Interval(testStart)
+	assert.Equal(t, testStart.Add(time.Hour), start)
+	assert.Equal(t, testStart.Add(2*time.Hour), end)
+
+	start, end = period.Interval(testStart.Add(2 * time.Hour))
+	assert.True(t, start.IsZero())
+	assert.True(t, end.IsZero())
+}
+
+func TestCronWindow(t *testing.T) {
+	schedule, err := scheduler.ParseCron("CRON_TZ=Europe/Bratislava 0 0 28-31 * *")
+	assert.Nil(t, err)
+	window, err := scheduler.CronWindow(schedule, 24*time.Hour)
+	assert.Nil(t, err)
+
+	location, err := time.LoadLocation("Europe/Bratislava")
+	assert.Nil(t, err)
+
+	// adjacent days are merged into one interval
+	start, end := window.Interval(time.Date(2020, 1, 15, 12, 0, 0, 0, location))
+	assert.Equal(t, time.Date(2020, 1, 28, 0, 0, 0, 0, location), start)
+	assert.Equal(t, time.Date(2020, 2, 1, 0, 0, 0, 0, location), end)
+
+	start, end = window.Interval(time.Date(2020, 1, 30, 12, 0, 0, 0, location))
+	assert.False(t, start.After(time.Date(2020, 1, 30, 12, 0, 0, 0, location)))
+	assert.Equal(t, time.Date(2020, 2, 1, 0, 0, 0, 0, location), end)
+
+	_, err = scheduler.CronWindow(schedule, 0)
+	assert.NotNil(t, err)
+}
+
+func TestBlackouts(t *testing.T) {
+	job := &Job{}
+
+	jobScheduler, fakeClock := newTestScheduler()
+	blackout := scheduler.Period{Start: testStart.Add(90 * time.Minute), End: testStart.Add(5*time.Hour + 30*time.Minute)}
+	err := jobScheduler.Add(job, time.Hour, scheduler.WithName("job"), scheduler.WithBlackouts(blackout))
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+
+	advance(t, jobScheduler, fakeClock, time.Hour, time.Hour)
+	assert.Equal(t, 1, job.Calls())
+	// runs during blackout are postponed until it ends
+	assert.Equal(t, blackout.End, jobScheduler.Jobs()[0].NextRun)
+
+	// manual run is refused during blackout unless forced
+	fakeClock.Set(testStart.Add(2 * time.Hour))
+	assert.EqualError(t, jobScheduler.RunNow("job"), "unable to run job job: job is in blackout or outside of its windows")
+	assert.Nil(t, jobScheduler.ForceRunNow("job"))
+	settle(t, jobScheduler, fakeClock)
+	assert.Equal(t, 2, job.Calls())
+
+	advance(t, jobScheduler, fakeClock, 4*time.Hour, 30*time.Minute)
+	jobScheduler.Stop()
+
+	// postponed run at 05:30 and regular run at 06:00
+	assert.Equal(t, 4, job.Calls())
+}
+
+func TestWindows(t *testing.T) {
+	job := &Job{}
+
+	schedule, err := scheduler.ParseCron("CRON_TZ=UTC 0 2 * * *")
+	assert.Nil(t, err)
+	window, err := scheduler.CronWindow(schedule, time.Hour)
+	assert.Nil(t, err)
+
+	jobScheduler, fakeClock := newTestScheduler()
+	err = jobScheduler.Add(job, 20*time.Minute, scheduler.WithWindows(window))
+	assert.Nil(t, err)
+
+	jobScheduler.Start()
+	assert.Equal(t, testStart.Add(2*time.Hour), jobScheduler.Jobs()[0].NextRun)
+
+	advance(t, jobScheduler, fakeClock, 4*time.Hour, 10*time.Minute)
+	next := jobScheduler.Jobs()[0].NextRun
+	jobScheduler.Stop()
+
+	// runs at 02:00, 02:20 and 02:40
+	assert.Equal(t, 3, job.Calls())
+	assert.Equal(t, testStart.Add(26*time.Hour), next)
+}